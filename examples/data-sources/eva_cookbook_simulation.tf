@@ -0,0 +1,18 @@
+data "eva_cookbook_simulation" "invoices" {
+    recipe = eva_cookbook.from_rules.recipe
+
+    events = [
+        {
+            type = "OrderInvoiced"
+            data = jsonencode({
+                Invoice = {
+                    TotalAmount = 100
+                }
+            })
+        },
+    ]
+}
+
+output "invoice_bookings" {
+    value = data.eva_cookbook_simulation.invoices.bookings
+}
@@ -0,0 +1,24 @@
+data "eva_stencil_preview" "order_confirmation" {
+    template_file = "${path.module}/templates/order-confirmation.hbs"
+
+    partials = {
+        address = "<p>{{Street}} {{HouseNumber}}, {{City}}</p>"
+    }
+
+    model = jsonencode({
+        Order = {
+            ID              = 1
+            ShippingAddress = {
+                Street      = "Main street"
+                HouseNumber = "1"
+                City        = "Amsterdam"
+            }
+        }
+    })
+}
+
+# Write the preview to a file, so it can be reviewed in a browser.
+resource "local_file" "order_confirmation_preview" {
+    filename = "${path.module}/preview/order-confirmation.html"
+    content  = data.eva_stencil_preview.order_confirmation.html
+}
@@ -8,4 +8,6 @@ provider "eva" {
 provider "eva" {
   endpoint = "https://api.eva.com"
   token    = "some-token"
+
+  request_timeout = "2m"
 }
@@ -0,0 +1,24 @@
+resource "eva_cookbook" "test" {
+    name      = "my recipe"
+    is_active = false
+    recipe    = file("${path.module}/recipes/my-recipe.txt")
+}
+
+resource "eva_cookbook" "from_rules" {
+    name = "my recipe from rules"
+
+    rules = [
+        {
+            name  = "invoices"
+            event = "OrderInvoiced"
+            bookings = [
+                {
+                    debit       = "1300"
+                    credit      = "8000"
+                    amount      = "Invoice.TotalAmount"
+                    description = "Invoiced orders"
+                },
+            ]
+        },
+    ]
+}
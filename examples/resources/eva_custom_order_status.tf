@@ -1,4 +1,5 @@
 resource "eva_custom_order_status" "example" {
     name            = "new status"
     description     = "new status for order"
+    adopt_existing  = true
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+# employees.csv:
+# email_address,first_name,last_name,password,employee_number
+# jane@domain.com,Jane,Doe,SomePassword1!,1001
+resource "eva_employees" "example" {
+  concurrency = 8
+  employees = [
+    for row in csvdecode(file("${path.module}/employees.csv")) : merge(row, {
+      primary_organization_unit_id = 1
+      roles = [
+        {
+          role_id              = 1
+          user_type            = 1
+          organization_unit_id = 1
+        }
+      ]
+    })
+  ]
+  timeouts {
+    create = "1h"
+    update = "1h"
+  }
+}
@@ -1,14 +1,24 @@
-
 resource "eva_open_id_provider" "provider" {
   name                = "provider"
   enabled             = true
   base_url            = "https://some-oauth-url.com"
   client_id           = "client-id"
+  client_secret       = "client-secret"
+  scopes              = ["profile", "email"]
   first_name_claim    = "given_name"
   last_name_claim     = "family_name"
   email_address_claim = "email"
   nickname_claim      = "name"
+  role_claim          = "roles"
   user_type           = 1
   create_users        = true
   primary             = true
+
+  default_organization_units = [
+    {
+      claim                = "groups"
+      value                = "store-employees"
+      organization_unit_id = 1
+    }
+  ]
 }
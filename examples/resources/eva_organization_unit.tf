@@ -7,6 +7,28 @@ resource "eva_organization_unit" "example" {
   parent_id     = 1
   currency_id   = "EUR"
   type          = 8
+  time_zone     = "Europe/Amsterdam"
+  gln           = "8712345678906"
+  vat_number    = "NL123456789B01"
+
+  opening_hours {
+    day = "monday"
+    ranges = [
+      { start_time = "09:00", end_time = "12:30" },
+      { start_time = "13:00", end_time = "18:00" },
+    ]
+  }
+
+  opening_hours {
+    day    = "saturday"
+    ranges = [{ start_time = "10:00", end_time = "17:00" }]
+  }
+
+  exceptions {
+    date        = "2022-12-25"
+    description = "Christmas"
+  }
+
   address = {
     address1     = "address 1"
     address2     = "address 2"
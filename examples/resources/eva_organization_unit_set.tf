@@ -0,0 +1,22 @@
+
+resource "eva_organization_unit_set" "flagship_shops" {
+  name        = "Flagship shops"
+  description = "Shops carrying the full assortment"
+  organization_unit_ids = [
+    eva_organization_unit.example.id,
+  ]
+}
+
+resource "eva_organization_unit_set" "dutch_shops" {
+  name = "Dutch shops"
+  filter = {
+    type       = 8
+    country_id = "NL"
+  }
+}
+
+resource "eva_setting" "dutch_shops_setting" {
+  key                      = "Some:Setting"
+  value                    = "some-value"
+  organization_unit_set_id = eva_organization_unit_set.dutch_shops.id
+}
@@ -0,0 +1,3 @@
+resource "eva_primary_open_id_provider" "primary" {
+  open_id_provider_id = eva_open_id_provider.provider.id
+}
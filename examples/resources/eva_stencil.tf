@@ -23,14 +23,14 @@ resource "eva_stencil" "test" {
 EOT
     footer          = "<footer></footer>"
     helpers         = "function someJavascriptFunction() {}"
-    type            = 1
-    layout          = 1
-    destination     = 1
+    type            = "template"
+    layout          = "my layout"
+    destinations    = ["mail", "pdf"]
     paper_properties = {
         wait_for_network_idle         = true
         wait_for_js                   = true
-        format                        = 1
-        orientation                   = 1
+        format                        = "A4"
+        orientation                   = "portrait"
         thermal_printer_template_type = 1
         size = {
             width               = "100"
@@ -44,4 +44,42 @@ EOT
             right  = 1
         }
     }
-}
\ No newline at end of file
+}
+
+resource "eva_stencil" "from_files" {
+    name          = "my stencil from files"
+    template_file = "${path.module}/templates/order-confirmation.hbs"
+    helpers_file  = "${path.module}/templates/helpers.js"
+    type          = "template"
+    destinations  = ["mail"]
+    language_id   = "en"
+
+    variants = [
+        {
+            language_id   = "nl"
+            template_file = "${path.module}/templates/order-confirmation.nl.hbs"
+        },
+        {
+            language_id = "de"
+            country_id  = "DE"
+            template    = "<h1>Vielen Dank für Ihre Bestellung</h1>"
+        },
+    ]
+}
+
+resource "eva_stencil" "address" {
+    name         = "address"
+    template     = "<p>{{Street}} {{HouseNumber}}</p><p>{{ZipCode}} {{City}}</p>"
+    type         = "partial"
+    destinations = ["mail"]
+}
+
+resource "eva_stencil" "with_partial" {
+    name         = "my stencil with a partial"
+    template     = "<h1>Shipping address</h1>{{> address Order.ShippingAddress}}"
+    type         = "template"
+    destinations = ["mail"]
+
+    # Partials are referenced by name, so Terraform has to create the partial first.
+    depends_on = [eva_stencil.address]
+}
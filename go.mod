@@ -3,11 +3,13 @@ module github.com/mad-it/terraform-provider-eva
 go 1.17
 
 require (
+	github.com/aymerick/raymond v2.0.2+incompatible
 	github.com/hashicorp/terraform-plugin-docs v0.5.1
 	github.com/hashicorp/terraform-plugin-framework v0.5.0
 	github.com/hashicorp/terraform-plugin-go v0.5.0
 	github.com/hashicorp/terraform-plugin-log v0.2.1
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.10.1
+	github.com/pmezard/go-difflib v1.0.0
 )
 
 require (
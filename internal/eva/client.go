@@ -1,6 +1,11 @@
 package eva
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
 	"github.com/go-resty/resty/v2"
 )
 
@@ -9,8 +14,14 @@ const (
 	userAgent   = "terraform-provider-eva"
 )
 
+// ErrNotFound is returned when the requested entity does not exist (anymore) in EVA.
+var ErrNotFound = errors.New("Entity not found.")
+
 type Client struct {
 	restClient *resty.Client
+
+	// listCache is shared by the copies of the client, so all resources of the provider share their list requests.
+	listCache *listCache
 }
 
 func NewClient(apiURL string) *Client {
@@ -18,16 +29,41 @@ func NewClient(apiURL string) *Client {
 	restClient := resty.New().
 		SetBaseURL(apiURL).
 		SetHeader("Content-Type", contentType).
-		SetHeader("EVA-User-Agent", userAgent).
-		SetDebug(true)
+		SetHeader("EVA-User-Agent", userAgent)
 
 	return &Client{
 		restClient: restClient,
+		listCache:  newListCache(),
 	}
 }
 
+// SetRequestTimeout bounds the duration of every single request to EVA.
+func (c *Client) SetRequestTimeout(timeout time.Duration) {
+	c.restClient.SetTimeout(timeout)
+}
+
 func (c *Client) SetAuthorizationHeader(token string) {
 	c.restClient.SetHeader("authorization", token)
 }
 
 type EmptyResponse struct {}
+
+// isNotFound checks whether EVA could not find the requested entity.
+// EVA responds with a 404 for unknown IDs, or with an error of type EntityNotFound.
+func isNotFound(resp *resty.Response) bool {
+	if resp.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	var errorResp struct {
+		Error struct {
+			Type string `json:"Type"`
+		} `json:"Error"`
+	}
+
+	if err := json.Unmarshal(resp.Body(), &errorResp); err != nil {
+		return false
+	}
+
+	return errorResp.Error.Type == "EntityNotFound"
+}
@@ -0,0 +1,72 @@
+package eva
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsNotFound(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError error
+	}{
+		{
+			name:          "found",
+			statusCode:    http.StatusOK,
+			body:          `{"Value": "value"}`,
+			expectedError: nil,
+		},
+		{
+			name:          "not found status",
+			statusCode:    http.StatusNotFound,
+			body:          ``,
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "entity not found error",
+			statusCode:    http.StatusBadRequest,
+			body:          `{"Error": {"Type": "EntityNotFound", "Message": "Organization unit not found."}}`,
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "other error",
+			statusCode:    http.StatusBadRequest,
+			body:          `{"Error": {"Type": "InvalidRequest", "Message": "Invalid key."}}`,
+			expectedError: errors.New("Request failed."),
+		},
+		{
+			name:          "error which is not JSON",
+			statusCode:    http.StatusInternalServerError,
+			body:          `Internal server error`,
+			expectedError: errors.New("Request failed."),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.statusCode)
+				w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL).GetSetting(context.Background(), GetSettingRequest{Key: "Key", OrganizationUnitID: 1})
+
+			if testCase.expectedError == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %s", err)
+				}
+				return
+			}
+
+			if err == nil || errors.Is(err, ErrNotFound) != errors.Is(testCase.expectedError, ErrNotFound) {
+				t.Errorf("expected %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -5,17 +5,45 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
-	getAccountingRecipePath    = "/api/cookbook/GetAccountingRecipe"
-	createAccountingRecipePath = "/api/cookbook/CreateAccountingRecipe"
-	updateAccountingRecipePath = "/api/cookbook/UpdateAccountingRecipe"
-	deleteAccountingRecipePath = "/api/cookbook/DeleteAccountingRecipe"
+	getAccountingRecipePath      = "/api/cookbook/GetAccountingRecipe"
+	createAccountingRecipePath   = "/api/cookbook/CreateAccountingRecipe"
+	updateAccountingRecipePath   = "/api/cookbook/UpdateAccountingRecipe"
+	deleteAccountingRecipePath   = "/api/cookbook/DeleteAccountingRecipe"
+	validateAccountingRecipePath = "/api/cookbook/ValidateAccountingRecipe"
+	activateAccountingRecipePath = "/api/cookbook/ActivateAccountingRecipe"
+	simulateAccountingRecipePath = "/api/cookbook/SimulateAccountingRecipe"
 )
 
+// RecipeError is an error in a recipe, with the position in the recipe where it was found.
+type RecipeError struct {
+	Message string `json:"Message"`
+	Line    int64  `json:"Line"`
+	Column  int64  `json:"Column"`
+}
+
+// RecipeErrors is returned when a recipe can't be compiled by EVA.
+type RecipeErrors []RecipeError
+
+func (e RecipeErrors) Error() string {
+	if len(e) == 0 {
+		return "Recipe has errors, but EVA didn't return which."
+	}
+
+	var messages []string
+
+	for _, recipeError := range e {
+		messages = append(messages, fmt.Sprintf("line %d, column %d: %s", recipeError.Line, recipeError.Column, recipeError.Message))
+	}
+
+	return fmt.Sprintf("Recipe has errors: %s", strings.Join(messages, "; "))
+}
+
 type CreateAccountingRecipeRequest struct {
 	Name     string `json:"Name"`
 	Recipe   string `json:"Recipe"`
@@ -23,12 +51,14 @@ type CreateAccountingRecipeRequest struct {
 }
 
 type CreateAccountingRecipeResponse struct {
-	ID        int64 `json:"ID"`
-	HasErrors bool  `json:"HasErrors"`
+	ID        int64         `json:"ID"`
+	HasErrors bool          `json:"HasErrors"`
+	Errors    []RecipeError `json:"Errors"`
 }
 
 func (c *Client) CreateAccountingRecipe(ctx context.Context, req CreateAccountingRecipeRequest) (*CreateAccountingRecipeResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createAccountingRecipePath)
 
@@ -45,11 +75,15 @@ func (c *Client) CreateAccountingRecipe(ctx context.Context, req CreateAccountin
 	}
 
 	var jsonResp CreateAccountingRecipeResponse
-	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil || jsonResp.HasErrors {
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
 
 		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
 	}
 
+	if jsonResp.HasErrors {
+		return nil, RecipeErrors(jsonResp.Errors)
+	}
+
 	return &jsonResp, nil
 }
 
@@ -71,6 +105,7 @@ type GetAccountingRecipeResponse struct {
 func (c *Client) GetAccountingRecipe(ctx context.Context, req GetAccountingRecipeRequest) (*GetAccountingRecipeResponse, error) {
 
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getAccountingRecipePath)
 
@@ -80,6 +115,12 @@ func (c *Client) GetAccountingRecipe(ctx context.Context, req GetAccountingRecip
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -101,15 +142,17 @@ type UpdateAccountingRecipeRequest struct {
 	ID       int64  `json:"ID"`
 	Name     string `json:"Name,omitempty"`
 	Recipe   string `json:"Recipe,omitempty"`
-	IsActive bool   `json:"IsActive,omitempty"`
+	IsActive bool   `json:"IsActive"`
 }
 
 type UpdateAccountingRecipeResponse struct {
-	HasErrors bool `json:"HasErrors"`
+	HasErrors bool          `json:"HasErrors"`
+	Errors    []RecipeError `json:"Errors"`
 }
 
 func (c *Client) UpdateAccountingRecipe(ctx context.Context, req UpdateAccountingRecipeRequest) (*UpdateAccountingRecipeResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateAccountingRecipePath)
 
@@ -126,10 +169,14 @@ func (c *Client) UpdateAccountingRecipe(ctx context.Context, req UpdateAccountin
 	}
 
 	var jsonResp UpdateAccountingRecipeResponse
-	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil || jsonResp.HasErrors {
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
 		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
 	}
 
+	if jsonResp.HasErrors {
+		return nil, RecipeErrors(jsonResp.Errors)
+	}
+
 	return &jsonResp, nil
 }
 
@@ -139,6 +186,7 @@ type DeleteAccountingRecipeRequest struct {
 
 func (c *Client) DeleteAccountingRecipe(ctx context.Context, req DeleteAccountingRecipeRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteAccountingRecipePath)
 
@@ -162,3 +210,135 @@ func (c *Client) DeleteAccountingRecipe(ctx context.Context, req DeleteAccountin
 
 	return &jsonResp, nil
 }
+
+type ValidateAccountingRecipeRequest struct {
+	Recipe string `json:"Recipe"`
+}
+
+type ValidateAccountingRecipeResponse struct {
+	HasErrors bool          `json:"HasErrors"`
+	Errors    []RecipeError `json:"Errors"`
+}
+
+// ValidateAccountingRecipe compiles a recipe without storing it, the errors in the recipe are returned as RecipeErrors.
+func (c *Client) ValidateAccountingRecipe(ctx context.Context, req ValidateAccountingRecipeRequest) (*ValidateAccountingRecipeResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(validateAccountingRecipePath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp ValidateAccountingRecipeResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	if jsonResp.HasErrors {
+		return nil, RecipeErrors(jsonResp.Errors)
+	}
+
+	return &jsonResp, nil
+}
+
+type ActivateAccountingRecipeRequest struct {
+	ID            int64   `json:"ID"`
+	DeactivateIDs []int64 `json:"DeactivateIDs,omitempty"`
+}
+
+// ActivateAccountingRecipe activates a recipe and deactivates the given recipes in a single request,
+// so there is no moment at which both or neither of the recipes are active.
+func (c *Client) ActivateAccountingRecipe(ctx context.Context, req ActivateAccountingRecipeRequest) (*EmptyResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(activateAccountingRecipePath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp EmptyResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
+
+type SimulatedEvent struct {
+	Type string          `json:"Type"`
+	Data json.RawMessage `json:"Data"`
+}
+
+type SimulateAccountingRecipeRequest struct {
+	Recipe string           `json:"Recipe"`
+	Events []SimulatedEvent `json:"Events"`
+}
+
+// SimulatedBooking is a booking made by a simulated recipe. The amount is kept as it is returned,
+// as a float64 can't represent every decimal amount exactly.
+type SimulatedBooking struct {
+	EventIndex    int64       `json:"EventIndex"`
+	Rule          string      `json:"Rule"`
+	DebitAccount  string      `json:"DebitAccount"`
+	CreditAccount string      `json:"CreditAccount"`
+	Amount        json.Number `json:"Amount"`
+	Description   string      `json:"Description"`
+}
+
+type SimulateAccountingRecipeResponse struct {
+	HasErrors bool               `json:"HasErrors"`
+	Errors    []RecipeError      `json:"Errors"`
+	Bookings  []SimulatedBooking `json:"Bookings"`
+}
+
+// SimulateAccountingRecipe returns the bookings a recipe makes for the events, without booking them.
+// The errors in the recipe are returned as RecipeErrors.
+func (c *Client) SimulateAccountingRecipe(ctx context.Context, req SimulateAccountingRecipeRequest) (*SimulateAccountingRecipeResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(simulateAccountingRecipePath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp SimulateAccountingRecipeResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	if jsonResp.HasErrors {
+		return nil, RecipeErrors(jsonResp.Errors)
+	}
+
+	return &jsonResp, nil
+}
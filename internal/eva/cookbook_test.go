@@ -0,0 +1,217 @@
+package eva
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestValidateAccountingRecipe(t *testing.T) {
+	testCases := []struct {
+		name           string
+		statusCode     int
+		body           string
+		isRecipeErrors bool
+		expectedErrors RecipeErrors
+		expectedError  string
+	}{
+		{
+			name:       "valid recipe",
+			statusCode: http.StatusOK,
+			body:       `{"HasErrors": false, "Errors": []}`,
+		},
+		{
+			name:       "valid recipe without errors",
+			statusCode: http.StatusOK,
+			body:       `{"HasErrors": false}`,
+		},
+		{
+			name:           "recipe with errors",
+			isRecipeErrors: true,
+			statusCode:     http.StatusOK,
+			body: `{"HasErrors": true, "Errors": [
+				{"Message": "Unknown event 'OrderInvoice'.", "Line": 2, "Column": 6},
+				{"Message": "Expected 'end'.", "Line": 4, "Column": 1}
+			]}`,
+			expectedErrors: RecipeErrors{
+				{Message: "Unknown event 'OrderInvoice'.", Line: 2, Column: 6},
+				{Message: "Expected 'end'.", Line: 4, Column: 1},
+			},
+			expectedError: "Recipe has errors: line 2, column 6: Unknown event 'OrderInvoice'.; line 4, column 1: Expected 'end'.",
+		},
+		{
+			name:           "recipe with errors which are not returned",
+			isRecipeErrors: true,
+			statusCode:     http.StatusOK,
+			body:           `{"HasErrors": true, "Errors": []}`,
+			expectedErrors: RecipeErrors{},
+			expectedError:  "Recipe has errors, but EVA didn't return which.",
+		},
+		{
+			name:           "recipe with errors which are null",
+			isRecipeErrors: true,
+			statusCode:     http.StatusOK,
+			body:           `{"HasErrors": true, "Errors": null}`,
+			expectedError:  "Recipe has errors, but EVA didn't return which.",
+		},
+		{
+			name:          "request failed",
+			statusCode:    http.StatusInternalServerError,
+			body:          `Internal server error`,
+			expectedError: "Request failed with error: Internal server error",
+		},
+		{
+			name:          "response which is not JSON",
+			statusCode:    http.StatusOK,
+			body:          `OK`,
+			expectedError: "Response could not be parsed. Received: OK",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				var req ValidateAccountingRecipeRequest
+
+				if r.URL.Path != validateAccountingRecipePath || json.Unmarshal(body, &req) != nil || req.Recipe != "recipe" {
+					t.Errorf("unexpected request to %s: %s", r.URL.Path, body)
+				}
+
+				w.WriteHeader(testCase.statusCode)
+				w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL).ValidateAccountingRecipe(context.Background(), ValidateAccountingRecipeRequest{Recipe: "recipe"})
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %s", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Fatalf("expected error %q, got %v", testCase.expectedError, err)
+			}
+
+			var recipeErrors RecipeErrors
+
+			if errors.As(err, &recipeErrors) != testCase.isRecipeErrors {
+				t.Fatalf("expected the error to be RecipeErrors: %t, got %T", testCase.isRecipeErrors, err)
+			}
+
+			if !reflect.DeepEqual(recipeErrors, testCase.expectedErrors) {
+				t.Errorf("expected errors %+v, got %+v", testCase.expectedErrors, recipeErrors)
+			}
+		})
+	}
+}
+
+func TestActivateAccountingRecipe(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           ActivateAccountingRecipeRequest
+		expectedBody  string
+		statusCode    int
+		body          string
+		expectedError string
+	}{
+		{
+			name:         "activate and deactivate",
+			req:          ActivateAccountingRecipeRequest{ID: 2, DeactivateIDs: []int64{1}},
+			expectedBody: `{"ID":2,"DeactivateIDs":[1]}`,
+			statusCode:   http.StatusOK,
+			body:         `{}`,
+		},
+		{
+			name:         "activate only",
+			req:          ActivateAccountingRecipeRequest{ID: 2},
+			expectedBody: `{"ID":2}`,
+			statusCode:   http.StatusOK,
+			body:         `{}`,
+		},
+		{
+			name:          "request failed",
+			req:           ActivateAccountingRecipeRequest{ID: 2, DeactivateIDs: []int64{1}},
+			expectedBody:  `{"ID":2,"DeactivateIDs":[1]}`,
+			statusCode:    http.StatusBadRequest,
+			body:          `{"Error": {"Type": "InvalidOperation", "Message": "Recipe 1 is not active."}}`,
+			expectedError: `Request failed with error: {"Error": {"Type": "InvalidOperation", "Message": "Recipe 1 is not active."}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if r.URL.Path != activateAccountingRecipePath || string(body) != testCase.expectedBody {
+					t.Errorf("expected a request to %s with %s, got a request to %s with %s", activateAccountingRecipePath, testCase.expectedBody, r.URL.Path, body)
+				}
+
+				w.WriteHeader(testCase.statusCode)
+				w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL).ActivateAccountingRecipe(context.Background(), testCase.req)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %s", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestUpdateAccountingRecipe(t *testing.T) {
+	testCases := []struct {
+		name         string
+		req          UpdateAccountingRecipeRequest
+		expectedBody string
+	}{
+		{
+			name:         "activate",
+			req:          UpdateAccountingRecipeRequest{ID: 1, Name: "recipe", Recipe: "rule", IsActive: true},
+			expectedBody: `{"ID":1,"Name":"recipe","Recipe":"rule","IsActive":true}`,
+		},
+		{
+			name:         "deactivate",
+			req:          UpdateAccountingRecipeRequest{ID: 1, Name: "recipe", Recipe: "rule", IsActive: false},
+			expectedBody: `{"ID":1,"Name":"recipe","Recipe":"rule","IsActive":false}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if r.URL.Path != updateAccountingRecipePath || string(body) != testCase.expectedBody {
+					t.Errorf("expected a request to %s with %s, got a request to %s with %s", updateAccountingRecipePath, testCase.expectedBody, r.URL.Path, body)
+				}
+
+				w.Write([]byte(`{"HasErrors": false}`))
+			}))
+			defer server.Close()
+
+			if _, err := NewClient(server.URL).UpdateAccountingRecipe(context.Background(), testCase.req); err != nil {
+				t.Errorf("expected no error, got %s", err)
+			}
+		})
+	}
+}
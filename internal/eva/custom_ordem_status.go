@@ -26,7 +26,10 @@ type CreateCustomOrderStatusResponse struct {
 }
 
 func (c *Client) CreateCustomOrderStatus(ctx context.Context, req CreateCustomOrderStatusRequest) (*CreateCustomOrderStatusResponse, error) {
+	defer c.listCache.invalidate(listCustomOrderStatusPath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createCustomOrderStatusPath)
 
@@ -61,8 +64,22 @@ type ListCustomOrderStatusResponse struct {
 	Result []CustomOrderStatus `json:"Result"`
 }
 
+// ListCustomOrderStatus returns the cached list when it was requested before.
 func (c *Client) ListCustomOrderStatus(ctx context.Context) (*ListCustomOrderStatusResponse, error) {
+	value, err := c.listCache.get(ctx, listCustomOrderStatusPath, func() (interface{}, error) {
+		return c.listCustomOrderStatus(ctx)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*ListCustomOrderStatusResponse), nil
+}
+
+func (c *Client) listCustomOrderStatus(ctx context.Context) (*ListCustomOrderStatusResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		Post(listCustomOrderStatusPath)
 
 	if err != nil {
@@ -94,7 +111,10 @@ type UpdateCustomOrderStatusRequest struct {
 }
 
 func (c *Client) UpdateCustomOrderStatus(ctx context.Context, req UpdateCustomOrderStatusRequest) (*EmptyResponse, error) {
+	defer c.listCache.invalidate(listCustomOrderStatusPath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateCustomOrderStatusPath)
 
@@ -124,7 +144,10 @@ type DeleteCustomOrderStatusRequest struct {
 }
 
 func (c *Client) DeleteCustomOrderStatus(ctx context.Context, req DeleteCustomOrderStatusRequest) (*EmptyResponse, error) {
+	defer c.listCache.invalidate(listCustomOrderStatusPath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteCustomOrderStatusPath)
 
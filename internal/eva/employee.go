@@ -20,13 +20,22 @@ const (
 	createEmployeePath = "/api/core/management/CreateEmployeeUser"
 	updateUserPath     = "/api/core/UpdateUser"
 	deleteUserPath     = "/api/core/DeleteUser"
+	deactivateUserPath = "/api/core/management/DeactivateUser"
 )
 
 type CreateEmployeeUserRequest struct {
-	FirstName    string `json:"FirstName"`
-	LastName     string `json:"LastName"`
-	EmailAddress string `json:"EmailAddress"`
-	Password     string `json:"Password"`
+	FirstName                 string `json:"FirstName"`
+	LastName                  string `json:"LastName"`
+	EmailAddress              string `json:"EmailAddress"`
+	Password                  string `json:"Password"`
+	Nickname                  string `json:"Nickname,omitempty"`
+	PhoneNumber               string `json:"PhoneNumber,omitempty"`
+	LanguageID                string `json:"LanguageID,omitempty"`
+	CountryID                 string `json:"CountryID,omitempty"`
+	DateOfBirth               string `json:"DateOfBirth,omitempty"`
+	EmployeeNumber            string `json:"EmployeeNumber,omitempty"`
+	BackendID                 string `json:"BackendID,omitempty"`
+	PrimaryOrganizationUnitID int64  `json:"PrimaryOrganizationUnitID,omitempty"`
 }
 
 type CreateEmployeeUserResponse struct {
@@ -36,6 +45,7 @@ type CreateEmployeeUserResponse struct {
 
 func (c *Client) CreateEmployee(ctx context.Context, req CreateEmployeeUserRequest) (*CreateEmployeeUserResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createEmployeePath)
 
@@ -64,14 +74,24 @@ type GetUserRequest struct {
 }
 
 type GetEmployeeResponse struct {
-	ID           int64  `json:"ID"`
-	FirstName    string `json:"FirstName"`
-	LastName     string `json:"LastName"`
-	EmailAddress string `json:"EmailAddress"`
+	ID                        int64  `json:"ID"`
+	FirstName                 string `json:"FirstName"`
+	LastName                  string `json:"LastName"`
+	EmailAddress              string `json:"EmailAddress"`
+	Nickname                  string `json:"Nickname"`
+	PhoneNumber               string `json:"PhoneNumber"`
+	LanguageID                string `json:"LanguageID"`
+	CountryID                 string `json:"CountryID"`
+	DateOfBirth               string `json:"DateOfBirth"`
+	EmployeeNumber            string `json:"EmployeeNumber"`
+	BackendID                 string `json:"BackendID"`
+	PrimaryOrganizationUnitID int64  `json:"PrimaryOrganizationUnitID"`
+	IsDeactivated             bool   `json:"IsDeactivated"`
 }
 
 func (c *Client) GetUser(ctx context.Context, req GetUserRequest) (*GetEmployeeResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getUserPath)
 
@@ -81,6 +101,12 @@ func (c *Client) GetUser(ctx context.Context, req GetUserRequest) (*GetEmployeeR
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -97,15 +123,26 @@ func (c *Client) GetUser(ctx context.Context, req GetUserRequest) (*GetEmployeeR
 	return &jsonResp, nil
 }
 
+// UpdateUserRequest updates all fields of the user, fields which are empty or null are cleared.
 type UpdateUserRequest struct {
-	ID           int64  `json:"ID"`
-	FirstName    string `json:"FirstName,omitempty"`
-	LastName     string `json:"LastName,omitempty"`
-	EmailAddress string `json:"EmailAddress,omitempty"`
+	ID                        int64   `json:"ID"`
+	FirstName                 string  `json:"FirstName"`
+	LastName                  string  `json:"LastName"`
+	EmailAddress              string  `json:"EmailAddress"`
+	Nickname                  string  `json:"Nickname"`
+	PhoneNumber               string  `json:"PhoneNumber"`
+	LanguageID                string  `json:"LanguageID"`
+	CountryID                 string  `json:"CountryID"`
+	DateOfBirth               *string `json:"DateOfBirth"`
+	EmployeeNumber            string  `json:"EmployeeNumber"`
+	BackendID                 string  `json:"BackendID"`
+	PrimaryOrganizationUnitID *int64  `json:"PrimaryOrganizationUnitID"`
+	IsDeactivated             *bool   `json:"IsDeactivated,omitempty"`
 }
 
 func (c *Client) UpdateUser(ctx context.Context, req UpdateUserRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateUserPath)
 
@@ -135,6 +172,7 @@ type DeleteUserRequest struct {
 
 func (c *Client) DeleteUser(ctx context.Context, req DeleteUserRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteUserPath)
 
@@ -157,3 +195,33 @@ func (c *Client) DeleteUser(ctx context.Context, req DeleteUserRequest) (*EmptyR
 
 	return &jsonResp, nil
 }
+
+type DeactivateUserRequest struct {
+	ID int64 `json:"UserID"`
+}
+
+func (c *Client) DeactivateUser(ctx context.Context, req DeactivateUserRequest) (*EmptyResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(deactivateUserPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp EmptyResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
@@ -0,0 +1,96 @@
+package eva
+
+import (
+	"context"
+	"sync"
+)
+
+// listCache caches the responses of list requests. Resources that are read by listing all entities of their kind
+// would otherwise all make the same request on every refresh. Concurrent requests for the same list share a single
+// request, failed requests are not cached. Changing an entity of a listed kind invalidates its list. Checks which
+// read every entity of a kind, like the stencils referring to a partial, cache those reads the same way.
+//
+// A shared request runs with the context of the caller that started it. When that context is cancelled or times out,
+// the callers waiting for it request the list again with their own context, instead of failing with its error.
+type listCache struct {
+	mutex   sync.Mutex
+	entries map[string]*listCacheEntry
+}
+
+type listCacheEntry struct {
+	// done is closed when the request finished.
+	done  chan struct{}
+	value interface{}
+	err   error
+	// aborted is set when the request failed because the context of the caller that started it was done.
+	aborted bool
+}
+
+func newListCache() *listCache {
+	return &listCache{
+		entries: map[string]*listCacheEntry{},
+	}
+}
+
+// get returns the cached list with the key, or requests the list with load when it is not cached. Load has to use ctx.
+func (c *listCache) get(ctx context.Context, key string, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+
+	for {
+		c.mutex.Lock()
+
+		if entry, ok := c.entries[key]; ok {
+			c.mutex.Unlock()
+
+			select {
+			case <-entry.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+
+			if entry.aborted {
+				continue
+			}
+
+			return entry.value, entry.err
+		}
+
+		entry := &listCacheEntry{done: make(chan struct{})}
+		c.entries[key] = entry
+		c.mutex.Unlock()
+
+		entry.value, entry.err = load()
+		entry.aborted = entry.err != nil && ctx.Err() != nil
+
+		if entry.err != nil {
+			c.remove(key, entry)
+		}
+
+		close(entry.done)
+
+		return entry.value, entry.err
+	}
+}
+
+// invalidate removes the cached list with the key, so it is requested again on the next get.
+func (c *listCache) invalidate(key string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *listCache) remove(key string, entry *listCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+}
@@ -0,0 +1,123 @@
+package eva
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListCustomOrderStatusCached(t *testing.T) {
+	var listRequests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case listCustomOrderStatusPath:
+			atomic.AddInt32(&listRequests, 1)
+			w.Write([]byte(`{"Result": [{"ID": 1, "Name": "pending"}]}`))
+		case createCustomOrderStatusPath:
+			w.Write([]byte(`{"ID": 2}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.ListCustomOrderStatus(ctx)
+
+			if err != nil || len(resp.Result) != 1 {
+				t.Errorf("expected one custom order status, got %v and error: %v", resp, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if listRequests != 1 {
+		t.Errorf("expected concurrent lists to share 1 request, got %d requests", listRequests)
+	}
+
+	if _, err := client.CreateCustomOrderStatus(ctx, CreateCustomOrderStatusRequest{Name: "completed"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := client.ListCustomOrderStatus(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if listRequests != 2 {
+		t.Errorf("expected creating a custom order status to invalidate the list, got %d requests", listRequests)
+	}
+}
+
+func TestListCustomOrderStatusCancelled(t *testing.T) {
+	var listRequests int32
+	started := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request hangs until its caller gives up.
+		if atomic.AddInt32(&listRequests, 1) == 1 {
+			close(started)
+			<-r.Context().Done()
+			return
+		}
+
+		w.Write([]byte(`{"Result": [{"ID": 1, "Name": "pending"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	firstErr := make(chan error)
+
+	go func() {
+		_, err := client.ListCustomOrderStatus(ctx)
+		firstErr <- err
+	}()
+
+	<-started
+
+	secondErr := make(chan error)
+
+	go func() {
+		resp, err := client.ListCustomOrderStatus(context.Background())
+
+		if err == nil && len(resp.Result) != 1 {
+			t.Errorf("expected one custom order status, got %v", resp)
+		}
+
+		secondErr <- err
+	}()
+
+	// Give the second caller time to wait for the request of the first caller.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first caller to be cancelled, got %v", err)
+	}
+
+	if err := <-secondErr; err != nil {
+		t.Errorf("expected the waiting caller to request the list again, got error: %v", err)
+	}
+
+	if listRequests != 2 {
+		t.Errorf("expected the list to be requested again after the cancelled request, got %d requests", listRequests)
+	}
+}
@@ -24,6 +24,7 @@ type LoginResponse struct {
 
 func (c *Client) Login(ctx context.Context, req LoginCredentials) error {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(loginPath)
 
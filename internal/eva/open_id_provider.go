@@ -17,17 +17,29 @@ const (
 	setPrimaryOpenIDProviderPath = "/api/authentication/openid/SetPrimaryOpenIDProvider"
 )
 
+// OpenIDProviderOrganizationUnitMapping assigns users with a value of a claim to an organization unit by default.
+type OpenIDProviderOrganizationUnitMapping struct {
+	Claim              string `json:"Claim"`
+	Value              string `json:"Value"`
+	OrganizationUnitID int64  `json:"OrganizationUnitID"`
+}
+
 type CreateOpenIDProviderRequest struct {
-	BaseUrl           string `json:"BaseUrl"`
-	ClientID          string `json:"ClientID"`
-	CreateUsers       bool   `json:"CreateUsers"`
-	EmailAddressClaim string `json:"EmailAddressClaim,omitempty"`
-	Enabled           bool   `json:"Enabled"`
-	FirstNameClaim    string `json:"FirstNameClaim,omitempty"`
-	LastNameClaim     string `json:"LastNameClaim,omitempty"`
-	Name              string `json:"Name,omitempty"`
-	NicknameClaim     string `json:"NicknameClaim,omitempty"`
-	UserType          int64  `json:"UserType"`
+	BaseUrl                  string                                  `json:"BaseUrl"`
+	ClientID                 string                                  `json:"ClientID"`
+	CreateUsers              bool                                    `json:"CreateUsers"`
+	EmailAddressClaim        string                                  `json:"EmailAddressClaim,omitempty"`
+	Enabled                  bool                                    `json:"Enabled"`
+	FirstNameClaim           string                                  `json:"FirstNameClaim,omitempty"`
+	LastNameClaim            string                                  `json:"LastNameClaim,omitempty"`
+	Name                     string                                  `json:"Name,omitempty"`
+	NicknameClaim            string                                  `json:"NicknameClaim,omitempty"`
+	UserType                 int64                                   `json:"UserType"`
+	ClientSecret             string                                  `json:"ClientSecret,omitempty"`
+	Scopes                   []string                                `json:"Scopes,omitempty"`
+	DiscoveryUrl             string                                  `json:"DiscoveryUrl,omitempty"`
+	RoleClaim                string                                  `json:"RoleClaim,omitempty"`
+	OrganizationUnitMappings []OpenIDProviderOrganizationUnitMapping `json:"OrganizationUnitMappings,omitempty"`
 }
 
 type CreateOpenIDProviderResponse struct {
@@ -36,6 +48,7 @@ type CreateOpenIDProviderResponse struct {
 
 func (c *Client) CreateOpenIDProvider(ctx context.Context, req CreateOpenIDProviderRequest) (*CreateOpenIDProviderResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createOpenIDProviderPath)
 
@@ -65,23 +78,28 @@ type GetOpenIDProviderRequest struct {
 }
 
 type GetOpenIDProviderResponse struct {
-	ID                int64  `json:"ID"`
-	BaseUrl           string `json:"BaseUrl"`
-	ClientID          string `json:"ClientID"`
-	CreateUsers       bool   `json:"CreateUsers"`
-	EmailAddressClaim string `json:"EmailAddressClaim,omitempty"`
-	Enabled           bool   `json:"Enabled"`
-	FirstNameClaim    string `json:"FirstNameClaim,omitempty"`
-	LastNameClaim     string `json:"LastNameClaim,omitempty"`
-	Name              string `json:"Name,omitempty"`
-	NicknameClaim     string `json:"NicknameClaim,omitempty"`
-	UserType          int64  `json:"UserType"`
-	Primary           bool   `json:"Primary"`
+	ID                       int64                                   `json:"ID"`
+	BaseUrl                  string                                  `json:"BaseUrl"`
+	ClientID                 string                                  `json:"ClientID"`
+	CreateUsers              bool                                    `json:"CreateUsers"`
+	EmailAddressClaim        string                                  `json:"EmailAddressClaim,omitempty"`
+	Enabled                  bool                                    `json:"Enabled"`
+	FirstNameClaim           string                                  `json:"FirstNameClaim,omitempty"`
+	LastNameClaim            string                                  `json:"LastNameClaim,omitempty"`
+	Name                     string                                  `json:"Name,omitempty"`
+	NicknameClaim            string                                  `json:"NicknameClaim,omitempty"`
+	UserType                 int64                                   `json:"UserType"`
+	Primary                  bool                                    `json:"Primary"`
+	Scopes                   []string                                `json:"Scopes"`
+	DiscoveryUrl             string                                  `json:"DiscoveryUrl"`
+	RoleClaim                string                                  `json:"RoleClaim"`
+	OrganizationUnitMappings []OpenIDProviderOrganizationUnitMapping `json:"OrganizationUnitMappings"`
 }
 
 func (c *Client) GetOpenIDProvider(ctx context.Context, req GetOpenIDProviderRequest) (*GetOpenIDProviderResponse, error) {
 
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getOpenIDProviderPath)
 
@@ -91,6 +109,12 @@ func (c *Client) GetOpenIDProvider(ctx context.Context, req GetOpenIDProviderReq
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -120,12 +144,19 @@ type UpdateOpenIDProviderRequest struct {
 	Name              string `json:"Name,omitempty"`
 	NicknameClaim     string `json:"NicknameClaim,omitempty"`
 	UserType          int64  `json:"UserType"`
+	// The client secret is only changed when it is sent.
+	ClientSecret             string                                  `json:"ClientSecret,omitempty"`
+	Scopes                   []string                                `json:"Scopes"`
+	DiscoveryUrl             string                                  `json:"DiscoveryUrl"`
+	RoleClaim                string                                  `json:"RoleClaim"`
+	OrganizationUnitMappings []OpenIDProviderOrganizationUnitMapping `json:"OrganizationUnitMappings"`
 }
 
 type UpdateOpenIDProviderResponse struct{}
 
 func (c *Client) UpdateOpenIDProvider(ctx context.Context, req UpdateOpenIDProviderRequest) (*UpdateOpenIDProviderResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateOpenIDProviderPath)
 
@@ -157,6 +188,7 @@ type DeleteOpenIDProviderResponse struct{}
 
 func (c *Client) DeleteOpenIDProvider(ctx context.Context, req DeleteOpenIDProviderRequest) (*DeleteOpenIDProviderResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteOpenIDProviderPath)
 
@@ -190,6 +222,7 @@ type SetPrimaryOpenIDProviderResponse struct{}
 func (c *Client) SetPrimaryOpenIDProvider(ctx context.Context, req SetPrimaryOpenIDProviderRequest) (*SetPrimaryOpenIDProviderResponse, error) {
 
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(setPrimaryOpenIDProviderPath)
 
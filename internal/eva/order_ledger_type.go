@@ -26,7 +26,10 @@ type CreateOrderLedgerTypeResponse struct {
 }
 
 func (c *Client) CreateOrderLedgerType(ctx context.Context, req CreateOrderLedgerTypeRequest) (*CreateOrderLedgerTypeResponse, error) {
+	defer c.listCache.invalidate(listOrderLedgerTypePath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createOrderLedgerTypePath)
 
@@ -61,8 +64,22 @@ type ListOrderLedgerTypeResponse struct {
 	Result []OrderLedgerType `json:"OrderLedgerTypes"`
 }
 
+// ListOrderLedgerTypes returns the cached list when it was requested before.
 func (c *Client) ListOrderLedgerTypes(ctx context.Context) (*ListOrderLedgerTypeResponse, error) {
+	value, err := c.listCache.get(ctx, listOrderLedgerTypePath, func() (interface{}, error) {
+		return c.listOrderLedgerTypes(ctx)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*ListOrderLedgerTypeResponse), nil
+}
+
+func (c *Client) listOrderLedgerTypes(ctx context.Context) (*ListOrderLedgerTypeResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		Post(listOrderLedgerTypePath)
 
 	if err != nil {
@@ -97,7 +114,10 @@ type UpdateOrderLedgerTypeResponse struct {
 }
 
 func (c *Client) UpdateOrderLedgerType(ctx context.Context, req UpdateOrderLedgerTypeRequest) (*UpdateOrderLedgerTypeResponse, error) {
+	defer c.listCache.invalidate(listOrderLedgerTypePath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateOrderLedgerTypePath)
 
@@ -130,7 +150,10 @@ type DeleteOrderLedgerTypeResponse struct {
 }
 
 func (c *Client) DeleteOrderLedgerType(ctx context.Context, req DeleteOrderLedgerTypeRequest) (*DeleteOrderLedgerTypeResponse, error) {
+	defer c.listCache.invalidate(listOrderLedgerTypePath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteOrderLedgerTypePath)
 
@@ -14,6 +14,7 @@ const (
 	createOrganizationUnitPath = "/api/core/CreateOrganizationUnit"
 	deleteOrganizationUnitPath = "/api/core/DeleteOrganizationUnit"
 	updateOrganizationUnitPath = "/api/core/UpdateOrganizationUnit"
+	moveOrganizationUnitPath   = "/api/core/management/MoveOrganizationUnit"
 )
 
 type Address struct {
@@ -36,6 +37,11 @@ type CreateOrganizationUnitRequest struct {
 	Type                int64    `json:"Type,omitempty"`
 	Latitude            float64  `json:"Latitude,omitempty"`
 	Longitude           float64  `json:"Longitude,omitempty"`
+	TimeZone            string   `json:"TimeZone,omitempty"`
+	GLN                 string   `json:"GlobalLocationNumber,omitempty"`
+	VatNumber           string   `json:"VatNumber,omitempty"`
+	RegistrationNumber  string   `json:"RegistrationNumber,omitempty"`
+	Subnet              string   `json:"Subnet,omitempty"`
 	Address             *Address `json:"Address,omitempty"`
 }
 
@@ -53,6 +59,7 @@ func (c *Client) CreateOrganizationUnit(ctx context.Context, req CreateOrganizat
 	}
 
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(requestBody).
 		Post(createOrganizationUnitPath)
 
@@ -82,15 +89,22 @@ type UpdateOrganizationUnitRequest struct {
 	Name                string   `json:"Name,omitempty"`
 	PhoneNumber         string   `json:"PhoneNumber,omitempty"`
 	EmailAddress        string   `json:"EmailAddress,omitempty"`
+	BackendID           string   `json:"BackendID"`
 	CostPriceCurrencyID string   `json:"CostPriceCurrencyID,omitempty"`
 	Type                int64    `json:"Type,omitempty"`
 	Latitude            float64  `json:"Latitude,omitempty"`
 	Longitude           float64  `json:"Longitude,omitempty"`
+	TimeZone            string   `json:"TimeZone"`
+	GLN                 string   `json:"GlobalLocationNumber"`
+	VatNumber           string   `json:"VatNumber"`
+	RegistrationNumber  string   `json:"RegistrationNumber"`
+	Subnet              string   `json:"Subnet"`
 	Address             *Address `json:"Address,omitempty"`
 }
 
 func (c *Client) UpdateOrganizationUnit(ctx context.Context, req UpdateOrganizationUnitRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateOrganizationUnitPath)
 
@@ -131,12 +145,18 @@ type GetOrganizationUnitDetailedResponse struct {
 	Type                int64    `json:"Type"`
 	Latitude            float64  `json:"Latitude"`
 	Longitude           float64  `json:"Longitude"`
+	TimeZone            string   `json:"TimeZone"`
+	GLN                 string   `json:"GlobalLocationNumber"`
+	VatNumber           string   `json:"VatNumber"`
+	RegistrationNumber  string   `json:"RegistrationNumber"`
+	Subnet              string   `json:"Subnet"`
 	Address             *Address `json:"Address,omitempty"`
 }
 
 func (c *Client) GetOrganizationUnitDetailed(ctx context.Context, req GetOrganizationUnitDetailedRequest) (*GetOrganizationUnitDetailedResponse, error) {
 
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getOrganizationUnitPath)
 
@@ -146,6 +166,12 @@ func (c *Client) GetOrganizationUnitDetailed(ctx context.Context, req GetOrganiz
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -169,6 +195,7 @@ type DeleteOrganizationUnitRequest struct {
 
 func (c *Client) DeleteOrganizationUnit(ctx context.Context, req DeleteOrganizationUnitRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteOrganizationUnitPath)
 
@@ -192,3 +219,35 @@ func (c *Client) DeleteOrganizationUnit(ctx context.Context, req DeleteOrganizat
 
 	return &jsonResp, nil
 }
+
+type MoveOrganizationUnitRequest struct {
+	ID       int64 `json:"OrganizationUnitID"`
+	ParentID int64 `json:"ParentID"`
+}
+
+func (c *Client) MoveOrganizationUnit(ctx context.Context, req MoveOrganizationUnitRequest) (*EmptyResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(moveOrganizationUnitPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp EmptyResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
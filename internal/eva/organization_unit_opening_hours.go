@@ -0,0 +1,100 @@
+package eva
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	getOrganizationUnitOpeningHoursPath = "/api/core/management/GetOrganizationUnitOpeningHours"
+	setOrganizationUnitOpeningHoursPath = "/api/core/management/SetOrganizationUnitOpeningHours"
+)
+
+type OpeningHoursRange struct {
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+}
+
+type OpeningHours struct {
+	// DayOfWeek starts at Sunday = 0, up to Saturday = 6.
+	DayOfWeek int64               `json:"DayOfWeek"`
+	Ranges    []OpeningHoursRange `json:"Ranges"`
+}
+
+type OpeningHoursException struct {
+	Date        string `json:"Date"`
+	Description string `json:"Description,omitempty"`
+}
+
+type GetOrganizationUnitOpeningHoursRequest struct {
+	OrganizationUnitID int64 `json:"OrganizationUnitID"`
+}
+
+type GetOrganizationUnitOpeningHoursResponse struct {
+	OpeningHours []OpeningHours          `json:"OpeningHours"`
+	Exceptions   []OpeningHoursException `json:"Exceptions"`
+}
+
+func (c *Client) GetOrganizationUnitOpeningHours(ctx context.Context, req GetOrganizationUnitOpeningHoursRequest) (*GetOrganizationUnitOpeningHoursResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(getOrganizationUnitOpeningHoursPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New("Request failed.")
+	}
+
+	tflog.Debug(ctx, "Request info", "Status code", resp.StatusCode(), "body", resp.String())
+
+	var jsonResp GetOrganizationUnitOpeningHoursResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Error: %s \n Received: %s", err, resp.String()))
+	}
+
+	return &jsonResp, nil
+}
+
+type SetOrganizationUnitOpeningHoursRequest struct {
+	OrganizationUnitID int64                   `json:"OrganizationUnitID"`
+	OpeningHours       []OpeningHours          `json:"OpeningHours"`
+	Exceptions         []OpeningHoursException `json:"Exceptions"`
+}
+
+func (c *Client) SetOrganizationUnitOpeningHours(ctx context.Context, req SetOrganizationUnitOpeningHoursRequest) (*EmptyResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(setOrganizationUnitOpeningHoursPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp EmptyResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
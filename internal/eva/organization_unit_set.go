@@ -0,0 +1,171 @@
+package eva
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	createOrganizationUnitSetPath = "/api/core/management/CreateOrganizationUnitSet"
+	getOrganizationUnitSetPath    = "/api/core/management/GetOrganizationUnitSet"
+	updateOrganizationUnitSetPath = "/api/core/management/UpdateOrganizationUnitSet"
+	deleteOrganizationUnitSetPath = "/api/core/management/DeleteOrganizationUnitSet"
+)
+
+// OrganizationUnitSetFilter describes a dynamic set, all organization units matching every filter belong to the set.
+type OrganizationUnitSetFilter struct {
+	OrganizationUnitType int64  `json:"OrganizationUnitType,omitempty"`
+	CountryID            string `json:"CountryID,omitempty"`
+	ParentID             int64  `json:"ParentID,omitempty"`
+}
+
+type CreateOrganizationUnitSetRequest struct {
+	Name                string                     `json:"Name"`
+	Description         string                     `json:"Description,omitempty"`
+	OrganizationUnitIDs []int64                    `json:"OrganizationUnitIDs,omitempty"`
+	Filter              *OrganizationUnitSetFilter `json:"Filter,omitempty"`
+}
+
+type CreateOrganizationUnitSetResponse struct {
+	ID int64 `json:"ID"`
+}
+
+func (c *Client) CreateOrganizationUnitSet(ctx context.Context, req CreateOrganizationUnitSetRequest) (*CreateOrganizationUnitSetResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(createOrganizationUnitSetPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp CreateOrganizationUnitSetResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
+
+type GetOrganizationUnitSetRequest struct {
+	ID int64 `json:"ID"`
+}
+
+type GetOrganizationUnitSetResponse struct {
+	ID                  int64                      `json:"ID"`
+	Name                string                     `json:"Name"`
+	Description         string                     `json:"Description"`
+	OrganizationUnitIDs []int64                    `json:"OrganizationUnitIDs"`
+	Filter              *OrganizationUnitSetFilter `json:"Filter"`
+}
+
+func (c *Client) GetOrganizationUnitSet(ctx context.Context, req GetOrganizationUnitSetRequest) (*GetOrganizationUnitSetResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(getOrganizationUnitSetPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New("Request failed.")
+	}
+
+	tflog.Debug(ctx, "Request info", "Status code", resp.StatusCode(), "body", resp.String())
+
+	var jsonResp GetOrganizationUnitSetResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Error: %s \n Received: %s", err, resp.String()))
+	}
+
+	return &jsonResp, nil
+}
+
+type UpdateOrganizationUnitSetRequest struct {
+	ID                  int64                      `json:"ID"`
+	Name                string                     `json:"Name"`
+	Description         string                     `json:"Description"`
+	OrganizationUnitIDs []int64                    `json:"OrganizationUnitIDs"`
+	Filter              *OrganizationUnitSetFilter `json:"Filter"`
+}
+
+func (c *Client) UpdateOrganizationUnitSet(ctx context.Context, req UpdateOrganizationUnitSetRequest) (*EmptyResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(updateOrganizationUnitSetPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp EmptyResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
+
+type DeleteOrganizationUnitSetRequest struct {
+	ID int64 `json:"ID"`
+}
+
+func (c *Client) DeleteOrganizationUnitSet(ctx context.Context, req DeleteOrganizationUnitSetRequest) (*EmptyResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(deleteOrganizationUnitSetPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp EmptyResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
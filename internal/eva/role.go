@@ -12,6 +12,7 @@ import (
 const (
 	createRolePath                    = "/api/core/management/CreateRole"
 	getRolePath                       = "/api/core/management/GetRole"
+	listRolesPath                     = "/api/core/management/ListRoles"
 	updateRolePath                    = "/api/core/management/UpdateRole"
 	deleteRolePath                    = "/api/core/management/DeleteRole"
 	attachFunctionalitiesToRolePath   = "/api/core/management/AttachFunctionalitiesToRole"
@@ -31,6 +32,7 @@ type CreateRoleResponse struct {
 
 func (c *Client) CreateRole(ctx context.Context, req CreateRoleRequest) (*CreateRoleResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createRolePath)
 
@@ -59,6 +61,7 @@ type GetRoleRequest struct {
 }
 
 type Role struct {
+	ID                    int64               `json:"ID,omitempty"`
 	Name                  string              `json:"Name"`
 	UserType              int64               `json:"UserType,omitempty"`
 	Code                  string              `json:"Code,omitempty"`
@@ -77,6 +80,7 @@ type GetRoleResponse struct {
 
 func (c *Client) GetRole(ctx context.Context, req GetRoleRequest) (*GetRoleResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getRolePath)
 
@@ -86,6 +90,12 @@ func (c *Client) GetRole(ctx context.Context, req GetRoleRequest) (*GetRoleRespo
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -103,6 +113,37 @@ func (c *Client) GetRole(ctx context.Context, req GetRoleRequest) (*GetRoleRespo
 	return &jsonResp, nil
 }
 
+type ListRolesResponse struct {
+	Result []Role `json:"Result"`
+}
+
+func (c *Client) ListRoles(ctx context.Context) (*ListRolesResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		Post(listRolesPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New("Request failed.")
+	}
+
+	tflog.Debug(ctx, "Request info", "Status code", resp.StatusCode(), "body", resp.String())
+
+	var jsonResp ListRolesResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
+
 type UpdateRoleRequest struct {
 	ID       int64  `json:"ID"`
 	Name     string `json:"Name,omitempty"`
@@ -112,6 +153,7 @@ type UpdateRoleRequest struct {
 
 func (c *Client) UpdateRole(ctx context.Context, req UpdateRoleRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateRolePath)
 
@@ -141,6 +183,7 @@ type DeleteRoleRequest struct {
 
 func (c *Client) DeleteRole(ctx context.Context, req DeleteRoleRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteRolePath)
 
@@ -172,6 +215,7 @@ type AttachFunctionalitiesToRoleRequest struct {
 
 func (c *Client) AttachFunctionalitiesToRole(ctx context.Context, req AttachFunctionalitiesToRoleRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(attachFunctionalitiesToRolePath)
 
@@ -202,6 +246,7 @@ type DetachFunctionalitiesFromRoleRequest struct {
 
 func (c *Client) DetachFunctionalitiesFromRole(ctx context.Context, req DetachFunctionalitiesFromRoleRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(detachFunctionalitiesFromRolePath)
 
@@ -244,6 +289,7 @@ type GetUserRoleResponse struct {
 
 func (c *Client) GetUserRole(ctx context.Context, req GetUserRoleRequest) (*GetUserRoleResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getUserRolePath)
 
@@ -282,6 +328,7 @@ type SetUserRoleRequest struct {
 
 func (c *Client) SetUserRole(ctx context.Context, req SetUserRoleRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(setUserRolePath)
 
@@ -16,13 +16,15 @@ const (
 )
 
 type SetSettingsRequest struct {
-	Key                string `json:"Key"`
-	Value              string `json:"Value"`
-	OrganizationUnitID int64  `json:"OrganizationUnitID,omitempty"`
+	Key                   string `json:"Key"`
+	Value                 string `json:"Value"`
+	OrganizationUnitID    int64  `json:"OrganizationUnitID,omitempty"`
+	OrganizationUnitSetID int64  `json:"OrganizationUnitSetID,omitempty"`
 }
 
 func (c *Client) SetSettings(ctx context.Context, req SetSettingsRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(setSettingPath)
 
@@ -48,8 +50,9 @@ func (c *Client) SetSettings(ctx context.Context, req SetSettingsRequest) (*Empt
 }
 
 type GetSettingRequest struct {
-	Key                string `json:"Key"`
-	OrganizationUnitID int64  `json:"OrganizationUnitID,omitempty"`
+	Key                   string `json:"Key"`
+	OrganizationUnitID    int64  `json:"OrganizationUnitID,omitempty"`
+	OrganizationUnitSetID int64  `json:"OrganizationUnitSetID,omitempty"`
 }
 
 type GetSettingResponse struct {
@@ -59,6 +62,7 @@ type GetSettingResponse struct {
 func (c *Client) GetSetting(ctx context.Context, req GetSettingRequest) (*GetSettingResponse, error) {
 
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getSettingPath)
 
@@ -68,6 +72,12 @@ func (c *Client) GetSetting(ctx context.Context, req GetSettingRequest) (*GetSet
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -86,12 +96,14 @@ func (c *Client) GetSetting(ctx context.Context, req GetSettingRequest) (*GetSet
 }
 
 type UnsetSettingsRequest struct {
-	Key                string `json:"Key,omitempty"`
-	OrganizationUnitID int64  `json:"OrganizationUnitID,omitempty"`
+	Key                   string `json:"Key,omitempty"`
+	OrganizationUnitID    int64  `json:"OrganizationUnitID,omitempty"`
+	OrganizationUnitSetID int64  `json:"OrganizationUnitSetID,omitempty"`
 }
 
 func (c *Client) UnsetSettings(ctx context.Context, req UnsetSettingsRequest) (*EmptyResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(unsetSettingPath)
 
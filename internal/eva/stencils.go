@@ -14,45 +14,50 @@ const (
 	getMessageTemplateByIDPath = "/api/core/management/GetMessageTemplateByID"
 	updateMessageTemplatePath  = "/api/core/management/UpdateMessageTemplate"
 	deleteMessageTemplatePath  = "/api/core/management/DeleteMessageTemplate"
+	listMessageTemplatesPath   = "/api/core/management/ListMessageTemplates"
 )
 
+// The paper properties are optional, so these are pointers. Properties that are not set are sent as null,
+// which also clears them when a stencil is updated.
+
 type PaperMargin struct {
-	Top    int64 `json:"Top"`
-	Left   int64 `json:"Left"`
-	Bottom int64 `json:"Bottom"`
-	Right  int64 `json:"Right"`
+	Top    *int64 `json:"Top"`
+	Left   *int64 `json:"Left"`
+	Bottom *int64 `json:"Bottom"`
+	Right  *int64 `json:"Right"`
 }
 
 type PaperSize struct {
-	Width             string  `json:"Width"`
-	Height            string  `json:"Height"`
-	DeviceScaleFactor float64 `json:"DeviceScaleFactor"`
+	Width             string   `json:"Width"`
+	Height            string   `json:"Height"`
+	DeviceScaleFactor *float64 `json:"DeviceScaleFactor"`
 }
 
 type PaperProperties struct {
-	WaitForNetworkIdle         bool        `json:"WaitForNetworkIdle"`
-	WaitForJS                  bool        `json:"WaitForJS"`
-	Size                       PaperSize   `json:"Size"`
-	Format                     int64       `json:"Format"`
-	Orientation                int64       `json:"Orientation"`
-	Margin                     PaperMargin `json:"PaperMargin"`
-	ThermalPrinterTemplateType int64       `json:"ThermalPrinterTemplateType"`
+	WaitForNetworkIdle         bool         `json:"WaitForNetworkIdle"`
+	WaitForJS                  bool         `json:"WaitForJS"`
+	Size                       *PaperSize   `json:"Size"`
+	Format                     *int64       `json:"Format"`
+	Orientation                *int64       `json:"Orientation"`
+	Margin                     *PaperMargin `json:"PaperMargin"`
+	ThermalPrinterTemplateType *int64       `json:"ThermalPrinterTemplateType"`
 }
 
 type CreateMessageTemplateRequest struct {
-	Name               string           `json:"Name"`
-	OrganizationUnitID int64            `json:"OrganizationUnitID,omitempty"`
-	LanguageID         string           `json:"LanguageID,omitempty"`
-	CountryID          string           `json:"CountryID,omitempty"`
-	Header             string           `json:"Header,omitempty"`
-	Template           string           `json:"Template"`
-	Footer             string           `json:"Footer,omitempty"`
-	Helpers            string           `json:"Helpers,omitempty"`
-	Type               int64            `json:"Type"`
-	Layout             string           `json:"Layout,omitempty"`
-	Destination        int64            `json:"Destination"`
-	PaperProperties    *PaperProperties `json:"PaperProperties,omitempty"` //omitempty doesn't work for struct unless it is a pointer
-	IsDisabled         bool             `json:"IsDisable,omitempty"`
+	Name                  string           `json:"Name"`
+	OrganizationUnitID    int64            `json:"OrganizationUnitID,omitempty"`
+	OrganizationUnitSetID int64            `json:"OrganizationUnitSetID,omitempty"`
+	LanguageID            string           `json:"LanguageID,omitempty"`
+	CountryID             string           `json:"CountryID,omitempty"`
+	Header                string           `json:"Header,omitempty"`
+	Template              string           `json:"Template"`
+	Footer                string           `json:"Footer,omitempty"`
+	Helpers               string           `json:"Helpers,omitempty"`
+	Type                  int64            `json:"Type"`
+	Layout                string           `json:"Layout,omitempty"`
+	Destination           int64            `json:"Destination"`
+	PaperProperties       *PaperProperties `json:"PaperProperties,omitempty"` //omitempty doesn't work for struct unless it is a pointer
+	IsDisabled            bool             `json:"IsDisable,omitempty"`
 }
 
 type CreateMessageTemplateResponse struct {
@@ -60,7 +65,10 @@ type CreateMessageTemplateResponse struct {
 }
 
 func (c *Client) CreateMessageTemplate(ctx context.Context, req CreateMessageTemplateRequest) (*CreateMessageTemplateResponse, error) {
+	defer c.listCache.invalidate(listMessageTemplatesPath)
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(createMessageTemplatePath)
 
@@ -90,24 +98,44 @@ type GetMessageTemplateByIDRequest struct {
 }
 
 type GetMessageTemplateByIDResponse struct {
-	ID                 int64            `json:"ID"`
-	Name               string           `json:"Name"`
-	OrganizationUnitID int64            `json:"OrganizationUnitID,omitempty"`
-	LanguageID         string           `json:"LanguageID"`
-	CountryID          string           `json:"CountryID"`
-	Header             string           `json:"Header"`
-	Template           string           `json:"Template"`
-	Footer             string           `json:"Footer"`
-	Helpers            string           `json:"Helpers"`
-	Type               int64            `json:"Type"`
-	Layout             string           `json:"Layout"`
-	Destination        int64            `json:"Destination"`
-	PaperProperties    *PaperProperties `json:"PaperProperties"`
-	IsDisabled         bool             `json:"IsDisable,omitempty"`
+	ID                    int64            `json:"ID"`
+	Name                  string           `json:"Name"`
+	OrganizationUnitID    int64            `json:"OrganizationUnitID,omitempty"`
+	OrganizationUnitSetID int64            `json:"OrganizationUnitSetID,omitempty"`
+	LanguageID            string           `json:"LanguageID"`
+	CountryID             string           `json:"CountryID"`
+	Header                string           `json:"Header"`
+	Template              string           `json:"Template"`
+	Footer                string           `json:"Footer"`
+	Helpers               string           `json:"Helpers"`
+	Type                  int64            `json:"Type"`
+	Layout                string           `json:"Layout"`
+	Destination           int64            `json:"Destination"`
+	PaperProperties       *PaperProperties `json:"PaperProperties"`
+	IsDisabled            bool             `json:"IsDisable,omitempty"`
+}
+
+// GetCachedMessageTemplateByID returns the cached message template when it was requested before with this method.
+// Checks which read every message template use it, so they read each message template once.
+func (c *Client) GetCachedMessageTemplateByID(ctx context.Context, req GetMessageTemplateByIDRequest) (*GetMessageTemplateByIDResponse, error) {
+	value, err := c.listCache.get(ctx, messageTemplateCacheKey(req.ID), func() (interface{}, error) {
+		return c.GetMessageTemplateByID(ctx, req)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*GetMessageTemplateByIDResponse), nil
+}
+
+func messageTemplateCacheKey(id int64) string {
+	return fmt.Sprintf("%s/%d", getMessageTemplateByIDPath, id)
 }
 
 func (c *Client) GetMessageTemplateByID(ctx context.Context, req GetMessageTemplateByIDRequest) (*GetMessageTemplateByIDResponse, error) {
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(getMessageTemplateByIDPath)
 
@@ -117,6 +145,12 @@ func (c *Client) GetMessageTemplateByID(ctx context.Context, req GetMessageTempl
 		return nil, err
 	}
 
+	if isNotFound(resp) {
+		tflog.Info(ctx, "Entity not found", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode() != 200 {
 		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
 
@@ -132,23 +166,28 @@ func (c *Client) GetMessageTemplateByID(ctx context.Context, req GetMessageTempl
 }
 
 type UpdateMessageTemplateRequest struct {
-	ID                 int64            `json:"ID"`
-	Name               string           `json:"Name"`
-	OrganizationUnitID int64            `json:"OrganizationUnitID,omitempty"`
-	LanguageID         string           `json:"LanguageID,omitempty"`
-	CountryID          string           `json:"CountryID,omitempty"`
-	Header             string           `json:"Header,omitempty"`
-	Template           string           `json:"Template"`
-	Footer             string           `json:"Footer,omitempty"`
-	Helpers            string           `json:"Helpers,omitempty"`
-	Layout             string           `json:"Layout,omitempty"`
-	Destination        int64            `json:"Destination"`
-	PaperProperties    *PaperProperties `json:"PaperProperties,omitempty"` //omitempty doesn't work for struct unless it is a pointer
-	IsDisabled         bool             `json:"IsDisable,omitempty"`
+	ID                    int64            `json:"ID"`
+	Name                  string           `json:"Name"`
+	OrganizationUnitID    int64            `json:"OrganizationUnitID,omitempty"`
+	OrganizationUnitSetID int64            `json:"OrganizationUnitSetID,omitempty"`
+	LanguageID            string           `json:"LanguageID,omitempty"`
+	CountryID             string           `json:"CountryID,omitempty"`
+	Header                string           `json:"Header,omitempty"`
+	Template              string           `json:"Template"`
+	Footer                string           `json:"Footer,omitempty"`
+	Helpers               string           `json:"Helpers,omitempty"`
+	Layout                string           `json:"Layout,omitempty"`
+	Destination           int64            `json:"Destination"`
+	PaperProperties       *PaperProperties `json:"PaperProperties"` // Sent as null when not set, so the properties are cleared.
+	IsDisabled            bool             `json:"IsDisable,omitempty"`
 }
 
 func (c *Client) UpdateMessageTemplate(ctx context.Context, req UpdateMessageTemplateRequest) (*EmptyResponse, error) {
+	defer c.listCache.invalidate(listMessageTemplatesPath)
+	defer c.listCache.invalidate(messageTemplateCacheKey(req.ID))
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(updateMessageTemplatePath)
 
@@ -178,7 +217,11 @@ type DeleteMessageTemplateRequesst struct {
 }
 
 func (c *Client) DeleteMessageTemplate(ctx context.Context, req DeleteMessageTemplateRequesst) (*EmptyResponse, error) {
+	defer c.listCache.invalidate(listMessageTemplatesPath)
+	defer c.listCache.invalidate(messageTemplateCacheKey(req.ID))
+
 	resp, err := c.restClient.R().
+		SetContext(ctx).
 		SetBody(req).
 		Post(deleteMessageTemplatePath)
 
@@ -202,3 +245,60 @@ func (c *Client) DeleteMessageTemplate(ctx context.Context, req DeleteMessageTem
 
 	return &jsonResp, nil
 }
+
+type ListMessageTemplatesRequest struct {
+	Type int64 `json:"Type,omitempty"`
+}
+
+type MessageTemplate struct {
+	ID   int64  `json:"ID"`
+	Name string `json:"Name"`
+	Type int64  `json:"Type"`
+}
+
+type ListMessageTemplatesResponse struct {
+	Result []MessageTemplate `json:"Result"`
+}
+
+// ListMessageTemplates returns the cached list when all message templates were requested before.
+func (c *Client) ListMessageTemplates(ctx context.Context, req ListMessageTemplatesRequest) (*ListMessageTemplatesResponse, error) {
+	if req.Type != 0 {
+		return c.listMessageTemplates(ctx, req)
+	}
+
+	value, err := c.listCache.get(ctx, listMessageTemplatesPath, func() (interface{}, error) {
+		return c.listMessageTemplates(ctx, req)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*ListMessageTemplatesResponse), nil
+}
+
+func (c *Client) listMessageTemplates(ctx context.Context, req ListMessageTemplatesRequest) (*ListMessageTemplatesResponse, error) {
+	resp, err := c.restClient.R().
+		SetContext(ctx).
+		SetBody(req).
+		Post(listMessageTemplatesPath)
+
+	if err != nil {
+		tflog.Error(ctx, "An network error ocurred.", err)
+
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		tflog.Info(ctx, "Request failed", "Status code", resp.StatusCode(), "body", resp.String())
+
+		return nil, errors.New(fmt.Sprintf("Request failed with error: %s", resp.String()))
+	}
+
+	var jsonResp ListMessageTemplatesResponse
+	if err := json.Unmarshal([]byte(resp.Body()), &jsonResp); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed. Received: %s", resp.String()))
+	}
+
+	return &jsonResp, nil
+}
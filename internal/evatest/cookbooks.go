@@ -0,0 +1,404 @@
+package evatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+type recipe struct {
+	ID       int64  `json:"ID"`
+	Name     string `json:"Name"`
+	Recipe   string `json:"Recipe"`
+	IsActive bool   `json:"IsActive"`
+}
+
+func (s *Server) registerCookbookHandlers() {
+	s.handle("/api/cookbook/CreateAccountingRecipe", s.createAccountingRecipe)
+	s.handle("/api/cookbook/GetAccountingRecipe", s.getAccountingRecipe)
+	s.handle("/api/cookbook/UpdateAccountingRecipe", s.updateAccountingRecipe)
+	s.handle("/api/cookbook/DeleteAccountingRecipe", s.deleteAccountingRecipe)
+	s.handle("/api/cookbook/ValidateAccountingRecipe", s.validateAccountingRecipe)
+	s.handle("/api/cookbook/ActivateAccountingRecipe", s.activateAccountingRecipe)
+	s.handle("/api/cookbook/SimulateAccountingRecipe", s.simulateAccountingRecipe)
+}
+
+func (s *Server) createAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.CreateAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, errors := compileRecipe(req.Recipe); len(errors) > 0 {
+		return eva.CreateAccountingRecipeResponse{HasErrors: true, Errors: errors}, nil
+	}
+
+	id := s.nextID()
+
+	s.recipes[id] = &recipe{
+		ID:       id,
+		Name:     req.Name,
+		Recipe:   req.Recipe,
+		IsActive: req.IsActive,
+	}
+
+	return eva.CreateAccountingRecipeResponse{ID: id}, nil
+}
+
+func (s *Server) getAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.GetAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	recipe, ok := s.recipes[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return map[string]interface{}{"Recipe": recipe}, nil
+}
+
+// updateAccountingRecipe only updates the name and recipe when they are set, and the activation when it is sent.
+func (s *Server) updateAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.UpdateAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	recipe, ok := s.recipes[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	if req.Recipe != "" {
+		if _, errors := compileRecipe(req.Recipe); len(errors) > 0 {
+			return eva.UpdateAccountingRecipeResponse{HasErrors: true, Errors: errors}, nil
+		}
+	}
+
+	updateString(&recipe.Name, req.Name)
+	updateString(&recipe.Recipe, req.Recipe)
+
+	if isSent(body, "IsActive") {
+		recipe.IsActive = req.IsActive
+	}
+
+	return eva.UpdateAccountingRecipeResponse{}, nil
+}
+
+func (s *Server) deleteAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.DeleteAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.recipes[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.recipes, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) validateAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.ValidateAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	_, errors := compileRecipe(req.Recipe)
+
+	return eva.ValidateAccountingRecipeResponse{HasErrors: len(errors) > 0, Errors: errors}, nil
+}
+
+func (s *Server) activateAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.ActivateAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	recipe, ok := s.recipes[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	for _, id := range req.DeactivateIDs {
+		if deactivated, ok := s.recipes[id]; ok {
+			deactivated.IsActive = false
+		}
+	}
+
+	recipe.IsActive = true
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) simulateAccountingRecipe(body []byte) (interface{}, error) {
+	var req eva.SimulateAccountingRecipeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	rules, errors := compileRecipe(req.Recipe)
+
+	if len(errors) > 0 {
+		return eva.SimulateAccountingRecipeResponse{HasErrors: true, Errors: errors}, nil
+	}
+
+	bookings := []eva.SimulatedBooking{}
+
+	for i, event := range req.Events {
+		var data interface{}
+
+		decoder := json.NewDecoder(bytes.NewReader(event.Data))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&data); err != nil {
+			return nil, fmt.Errorf("Data of event %d is not valid JSON: %s", i, err)
+		}
+
+		for _, rule := range rules {
+			if rule.event != event.Type {
+				continue
+			}
+
+			if rule.condition != nil {
+				matches, err := rule.condition.evaluate(data)
+
+				if err != nil {
+					return eva.SimulateAccountingRecipeResponse{HasErrors: true, Errors: []eva.RecipeError{*err}}, nil
+				}
+
+				if !matches {
+					continue
+				}
+			}
+
+			for _, booking := range rule.bookings {
+				amount, err := booking.amount.evaluate(data)
+
+				if err != nil {
+					return eva.SimulateAccountingRecipeResponse{HasErrors: true, Errors: []eva.RecipeError{*err}}, nil
+				}
+
+				bookings = append(bookings, eva.SimulatedBooking{
+					EventIndex:    int64(i),
+					Rule:          rule.name,
+					DebitAccount:  booking.debit,
+					CreditAccount: booking.credit,
+					Amount:        amount,
+					Description:   booking.description,
+				})
+			}
+		}
+	}
+
+	return eva.SimulateAccountingRecipeResponse{Bookings: bookings}, nil
+}
+
+// The fake compiles the subset of the cookbook language the provider renders from rules:
+//
+//	rule "<name>"
+//	  on <event>
+//	  when <value> <operator> <value>
+//	  book <value> from <credit> to <debit> as "<description>"
+//	end
+//
+// Values are numbers or paths into the data of the event, e.g. Invoice.TotalAmount.
+
+var (
+	ruleStatement = regexp.MustCompile(`^rule ("(?:[^"\\]|\\.)*")$`)
+	onStatement   = regexp.MustCompile(`^on (\w+)$`)
+	whenStatement = regexp.MustCompile(`^when (\S+) (==|!=|>=|<=|>|<) (\S+)$`)
+	bookStatement = regexp.MustCompile(`^book (\S+) from (\S+) to (\S+)(?: as ("(?:[^"\\]|\\.)*"))?$`)
+	endStatement  = regexp.MustCompile(`^end$`)
+)
+
+type compiledRule struct {
+	name      string
+	event     string
+	condition *compiledCondition
+	bookings  []compiledBooking
+}
+
+type compiledCondition struct {
+	left     compiledValue
+	operator string
+	right    compiledValue
+}
+
+type compiledBooking struct {
+	amount      compiledValue
+	credit      string
+	debit       string
+	description string
+}
+
+type compiledValue struct {
+	expression string
+	line       int64
+	column     int64
+}
+
+func compileRecipe(source string) ([]compiledRule, []eva.RecipeError) {
+	var rules []compiledRule
+	var errors []eva.RecipeError
+	var current *compiledRule
+
+	addError := func(line int, column int, format string, args ...interface{}) {
+		errors = append(errors, eva.RecipeError{
+			Message: fmt.Sprintf(format, args...),
+			Line:    int64(line),
+			Column:  int64(column),
+		})
+	}
+
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		statement := strings.Join(strings.Fields(line), " ")
+		lineNumber := i + 1
+		column := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+
+		if statement == "" {
+			continue
+		}
+
+		if current == nil {
+			match := ruleStatement.FindStringSubmatch(statement)
+
+			if match == nil {
+				addError(lineNumber, column, "Expected a rule, got %q.", statement)
+				continue
+			}
+
+			name, _ := strconv.Unquote(match[1])
+			current = &compiledRule{name: name}
+			continue
+		}
+
+		value := func(expression string) compiledValue {
+			return compiledValue{
+				expression: expression,
+				line:       int64(lineNumber),
+				column:     int64(column + strings.Index(statement, expression)),
+			}
+		}
+
+		if match := onStatement.FindStringSubmatch(statement); match != nil {
+			current.event = match[1]
+		} else if match := whenStatement.FindStringSubmatch(statement); match != nil {
+			current.condition = &compiledCondition{left: value(match[1]), operator: match[2], right: value(match[3])}
+		} else if match := bookStatement.FindStringSubmatch(statement); match != nil {
+			description, _ := strconv.Unquote(match[4])
+
+			current.bookings = append(current.bookings, compiledBooking{
+				amount:      value(match[1]),
+				credit:      match[2],
+				debit:       match[3],
+				description: description,
+			})
+		} else if endStatement.MatchString(statement) {
+			if current.event == "" {
+				addError(lineNumber, column, "Rule %q has no event.", current.name)
+			}
+
+			if len(current.bookings) == 0 {
+				addError(lineNumber, column, "Rule %q has no bookings.", current.name)
+			}
+
+			rules = append(rules, *current)
+			current = nil
+		} else {
+			addError(lineNumber, column, "Unexpected statement %q in rule %q.", statement, current.name)
+		}
+	}
+
+	if current != nil {
+		addError(len(lines), 1, "Rule %q is not ended.", current.name)
+	}
+
+	return rules, errors
+}
+
+func (c compiledCondition) evaluate(data interface{}) (bool, *eva.RecipeError) {
+	leftNumber, err := c.left.evaluate(data)
+
+	if err != nil {
+		return false, err
+	}
+
+	rightNumber, err := c.right.evaluate(data)
+
+	if err != nil {
+		return false, err
+	}
+
+	left, _ := leftNumber.Float64()
+	right, _ := rightNumber.Float64()
+
+	switch c.operator {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	case ">=":
+		return left >= right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	default:
+		return left < right, nil
+	}
+}
+
+// evaluate returns the number, or the number at the path in the data. Numbers are returned as they are written,
+// so amounts are booked exactly.
+func (v compiledValue) evaluate(data interface{}) (json.Number, *eva.RecipeError) {
+	if _, err := strconv.ParseFloat(v.expression, 64); err == nil {
+		return json.Number(v.expression), nil
+	}
+
+	value := data
+
+	for _, name := range strings.Split(v.expression, ".") {
+		object, ok := value.(map[string]interface{})
+
+		if !ok {
+			value = nil
+			break
+		}
+
+		value = object[name]
+	}
+
+	number, ok := value.(json.Number)
+
+	if !ok {
+		return "", &eva.RecipeError{
+			Message: fmt.Sprintf("%s is not a number in the event.", v.expression),
+			Line:    v.line,
+			Column:  v.column,
+		}
+	}
+
+	return number, nil
+}
@@ -0,0 +1,151 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+func (s *Server) registerEmployeeHandlers() {
+	s.handle("/api/core/management/CreateEmployeeUser", s.createEmployeeUser)
+	s.handle("/api/core/GetUser", s.getUser)
+	s.handle("/api/core/UpdateUser", s.updateUser)
+	s.handle("/api/core/DeleteUser", s.deleteUser)
+	s.handle("/api/core/management/DeactivateUser", s.deactivateUser)
+}
+
+// createEmployeeUser creates an employee, or updates the existing user with the same email address like EVA does.
+func (s *Server) createEmployeeUser(body []byte) (interface{}, error) {
+	var req eva.CreateEmployeeUserRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	employee := eva.GetEmployeeResponse{
+		FirstName:                 req.FirstName,
+		LastName:                  req.LastName,
+		EmailAddress:              req.EmailAddress,
+		Nickname:                  req.Nickname,
+		PhoneNumber:               req.PhoneNumber,
+		LanguageID:                req.LanguageID,
+		CountryID:                 req.CountryID,
+		DateOfBirth:               dateTime(req.DateOfBirth),
+		EmployeeNumber:            req.EmployeeNumber,
+		BackendID:                 req.BackendID,
+		PrimaryOrganizationUnitID: req.PrimaryOrganizationUnitID,
+	}
+
+	for _, id := range sortedIDs(s.users) {
+		if s.users[id].EmailAddress == req.EmailAddress {
+			employee.ID = id
+			s.users[id] = &employee
+
+			return eva.CreateEmployeeUserResponse{ID: id, Result: eva.UpdatedExistingUser}, nil
+		}
+	}
+
+	employee.ID = s.nextID()
+	s.users[employee.ID] = &employee
+
+	return eva.CreateEmployeeUserResponse{ID: employee.ID, Result: eva.CreatedNewUser}, nil
+}
+
+func (s *Server) getUser(body []byte) (interface{}, error) {
+	var req eva.GetUserRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	user, ok := s.users[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return user, nil
+}
+
+// updateUser updates all fields of the user, except for the activation which is only updated when set.
+func (s *Server) updateUser(body []byte) (interface{}, error) {
+	var req eva.UpdateUserRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	user, ok := s.users[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	user.FirstName = req.FirstName
+	user.LastName = req.LastName
+	user.EmailAddress = req.EmailAddress
+	user.Nickname = req.Nickname
+	user.PhoneNumber = req.PhoneNumber
+	user.LanguageID = req.LanguageID
+	user.CountryID = req.CountryID
+	user.DateOfBirth = ""
+	user.EmployeeNumber = req.EmployeeNumber
+	user.BackendID = req.BackendID
+	user.PrimaryOrganizationUnitID = 0
+
+	if req.DateOfBirth != nil {
+		user.DateOfBirth = dateTime(*req.DateOfBirth)
+	}
+
+	if req.PrimaryOrganizationUnitID != nil {
+		user.PrimaryOrganizationUnitID = *req.PrimaryOrganizationUnitID
+	}
+
+	if req.IsDeactivated != nil {
+		user.IsDeactivated = *req.IsDeactivated
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deleteUser(body []byte) (interface{}, error) {
+	var req eva.DeleteUserRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.users[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.users, req.ID)
+	delete(s.userRoles, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deactivateUser(body []byte) (interface{}, error) {
+	var req eva.DeactivateUserRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	user, ok := s.users[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	user.IsDeactivated = true
+
+	return eva.EmptyResponse{}, nil
+}
+
+// dateTime returns the date as the timestamp EVA returns for dates.
+func dateTime(date string) string {
+	if date == "" {
+		return ""
+	}
+
+	return date + "T00:00:00Z"
+}
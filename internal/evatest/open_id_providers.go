@@ -0,0 +1,130 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+func (s *Server) registerOpenIDProviderHandlers() {
+	s.handle("/api/authentication/openid/CreateOpenIDProvider", s.createOpenIDProvider)
+	s.handle("/api/authentication/openid/GetOpenIDProviderByID", s.getOpenIDProvider)
+	s.handle("/api/authentication/openid/UpdateOpenIDProvider", s.updateOpenIDProvider)
+	s.handle("/api/authentication/openid/DeleteOpenIDProvider", s.deleteOpenIDProvider)
+	s.handle("/api/authentication/openid/SetPrimaryOpenIDProvider", s.setPrimaryOpenIDProvider)
+}
+
+// createOpenIDProvider creates the OpenID provider, the client secret is not kept as EVA never returns it.
+func (s *Server) createOpenIDProvider(body []byte) (interface{}, error) {
+	var req eva.CreateOpenIDProviderRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	id := s.nextID()
+
+	s.openIDProviders[id] = &eva.GetOpenIDProviderResponse{
+		ID:                       id,
+		BaseUrl:                  req.BaseUrl,
+		ClientID:                 req.ClientID,
+		CreateUsers:              req.CreateUsers,
+		EmailAddressClaim:        req.EmailAddressClaim,
+		Enabled:                  req.Enabled,
+		FirstNameClaim:           req.FirstNameClaim,
+		LastNameClaim:            req.LastNameClaim,
+		Name:                     req.Name,
+		NicknameClaim:            req.NicknameClaim,
+		UserType:                 req.UserType,
+		Scopes:                   req.Scopes,
+		DiscoveryUrl:             req.DiscoveryUrl,
+		RoleClaim:                req.RoleClaim,
+		OrganizationUnitMappings: req.OrganizationUnitMappings,
+	}
+
+	return eva.CreateOpenIDProviderResponse{ID: id}, nil
+}
+
+func (s *Server) getOpenIDProvider(body []byte) (interface{}, error) {
+	var req eva.GetOpenIDProviderRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	openIDProvider, ok := s.openIDProviders[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return openIDProvider, nil
+}
+
+func (s *Server) updateOpenIDProvider(body []byte) (interface{}, error) {
+	var req eva.UpdateOpenIDProviderRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	openIDProvider, ok := s.openIDProviders[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	s.openIDProviders[req.ID] = &eva.GetOpenIDProviderResponse{
+		ID:                       req.ID,
+		BaseUrl:                  req.BaseUrl,
+		ClientID:                 req.ClientID,
+		CreateUsers:              req.CreateUsers,
+		EmailAddressClaim:        req.EmailAddressClaim,
+		Enabled:                  req.Enabled,
+		FirstNameClaim:           req.FirstNameClaim,
+		LastNameClaim:            req.LastNameClaim,
+		Name:                     req.Name,
+		NicknameClaim:            req.NicknameClaim,
+		UserType:                 req.UserType,
+		Primary:                  openIDProvider.Primary,
+		Scopes:                   req.Scopes,
+		DiscoveryUrl:             req.DiscoveryUrl,
+		RoleClaim:                req.RoleClaim,
+		OrganizationUnitMappings: req.OrganizationUnitMappings,
+	}
+
+	return eva.UpdateOpenIDProviderResponse{}, nil
+}
+
+func (s *Server) deleteOpenIDProvider(body []byte) (interface{}, error) {
+	var req eva.DeleteOpenIDProviderRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.openIDProviders[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.openIDProviders, req.ID)
+
+	return eva.DeleteOpenIDProviderResponse{}, nil
+}
+
+// setPrimaryOpenIDProvider makes the OpenID provider primary, and all others not.
+func (s *Server) setPrimaryOpenIDProvider(body []byte) (interface{}, error) {
+	var req eva.SetPrimaryOpenIDProviderRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.openIDProviders[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	for id, openIDProvider := range s.openIDProviders {
+		openIDProvider.Primary = id == req.ID
+	}
+
+	return eva.SetPrimaryOpenIDProviderResponse{}, nil
+}
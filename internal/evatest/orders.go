@@ -0,0 +1,144 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+func (s *Server) registerOrderHandlers() {
+	s.handle("/api/core/CreateCustomOrderStatus", s.createCustomOrderStatus)
+	s.handle("/api/core/ListCustomOrderStatus", s.listCustomOrderStatus)
+	s.handle("/api/core/UpdateCustomOrderStatus", s.updateCustomOrderStatus)
+	s.handle("/api/core/DeleteCustomOrderStatus", s.deleteCustomOrderStatus)
+	s.handle("/api/core/management/CreateOrderLedgerType", s.createOrderLedgerType)
+	s.handle("/api/core/management/ListOrderLedgerTypes", s.listOrderLedgerTypes)
+	s.handle("/api/core/management/UpdateOrderLedgerType", s.updateOrderLedgerType)
+	s.handle("/api/core/management/DeleteOrderLedgerType", s.deleteOrderLedgerType)
+}
+
+func (s *Server) createCustomOrderStatus(body []byte) (interface{}, error) {
+	var req eva.CreateCustomOrderStatusRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	id := s.nextID()
+
+	s.customOrderStatuses[id] = &eva.CustomOrderStatus{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	return eva.CreateCustomOrderStatusResponse{ID: id}, nil
+}
+
+func (s *Server) listCustomOrderStatus(body []byte) (interface{}, error) {
+	customOrderStatuses := []eva.CustomOrderStatus{}
+
+	for _, id := range sortedIDs(s.customOrderStatuses) {
+		customOrderStatuses = append(customOrderStatuses, *s.customOrderStatuses[id])
+	}
+
+	return eva.ListCustomOrderStatusResponse{Result: customOrderStatuses}, nil
+}
+
+func (s *Server) updateCustomOrderStatus(body []byte) (interface{}, error) {
+	var req eva.UpdateCustomOrderStatusRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.customOrderStatuses[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	s.customOrderStatuses[req.ID] = &eva.CustomOrderStatus{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deleteCustomOrderStatus(body []byte) (interface{}, error) {
+	var req eva.DeleteCustomOrderStatusRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.customOrderStatuses[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.customOrderStatuses, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) createOrderLedgerType(body []byte) (interface{}, error) {
+	var req eva.CreateOrderLedgerTypeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	id := s.nextID()
+
+	s.orderLedgerTypes[id] = &eva.OrderLedgerType{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	return eva.CreateOrderLedgerTypeResponse{ID: id}, nil
+}
+
+func (s *Server) listOrderLedgerTypes(body []byte) (interface{}, error) {
+	orderLedgerTypes := []eva.OrderLedgerType{}
+
+	for _, id := range sortedIDs(s.orderLedgerTypes) {
+		orderLedgerTypes = append(orderLedgerTypes, *s.orderLedgerTypes[id])
+	}
+
+	return eva.ListOrderLedgerTypeResponse{Result: orderLedgerTypes}, nil
+}
+
+func (s *Server) updateOrderLedgerType(body []byte) (interface{}, error) {
+	var req eva.UpdateOrderLedgerTypeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.orderLedgerTypes[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	s.orderLedgerTypes[req.ID] = &eva.OrderLedgerType{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	return eva.UpdateOrderLedgerTypeResponse{}, nil
+}
+
+func (s *Server) deleteOrderLedgerType(body []byte) (interface{}, error) {
+	var req eva.DeleteOrderLedgerTypeRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.orderLedgerTypes[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.orderLedgerTypes, req.ID)
+
+	return eva.DeleteOrderLedgerTypeResponse{}, nil
+}
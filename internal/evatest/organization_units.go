@@ -0,0 +1,278 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+type organizationUnit struct {
+	eva.GetOrganizationUnitDetailedResponse
+
+	openingHours eva.GetOrganizationUnitOpeningHoursResponse
+}
+
+func (s *Server) registerOrganizationUnitHandlers() {
+	s.handle("/api/core/CreateOrganizationUnit", s.createOrganizationUnit)
+	s.handle("/api/core/GetOrganizationUnitDetailed", s.getOrganizationUnitDetailed)
+	s.handle("/api/core/UpdateOrganizationUnit", s.updateOrganizationUnit)
+	s.handle("/api/core/DeleteOrganizationUnit", s.deleteOrganizationUnit)
+	s.handle("/api/core/management/MoveOrganizationUnit", s.moveOrganizationUnit)
+	s.handle("/api/core/management/GetOrganizationUnitOpeningHours", s.getOrganizationUnitOpeningHours)
+	s.handle("/api/core/management/SetOrganizationUnitOpeningHours", s.setOrganizationUnitOpeningHours)
+	s.handle("/api/core/management/CreateOrganizationUnitSet", s.createOrganizationUnitSet)
+	s.handle("/api/core/management/GetOrganizationUnitSet", s.getOrganizationUnitSet)
+	s.handle("/api/core/management/UpdateOrganizationUnitSet", s.updateOrganizationUnitSet)
+	s.handle("/api/core/management/DeleteOrganizationUnitSet", s.deleteOrganizationUnitSet)
+}
+
+func (s *Server) createOrganizationUnit(body []byte) (interface{}, error) {
+	var req struct {
+		ToCreate eva.CreateOrganizationUnitRequest `json:"ToCreate"`
+	}
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	toCreate := req.ToCreate
+	id := s.nextID()
+
+	s.organizationUnits[id] = &organizationUnit{
+		GetOrganizationUnitDetailedResponse: eva.GetOrganizationUnitDetailedResponse{
+			ID:                  id,
+			Name:                toCreate.Name,
+			PhoneNumber:         toCreate.PhoneNumber,
+			EmailAddress:        toCreate.EmailAddress,
+			ParentID:            toCreate.ParentID,
+			CurrencyID:          toCreate.CurrencyID,
+			BackendID:           toCreate.BackendID,
+			CostPriceCurrencyID: toCreate.CostPriceCurrencyID,
+			Type:                toCreate.Type,
+			Latitude:            toCreate.Latitude,
+			Longitude:           toCreate.Longitude,
+			TimeZone:            toCreate.TimeZone,
+			GLN:                 toCreate.GLN,
+			VatNumber:           toCreate.VatNumber,
+			RegistrationNumber:  toCreate.RegistrationNumber,
+			Subnet:              toCreate.Subnet,
+			Address:             toCreate.Address,
+		},
+	}
+
+	return eva.CreateOrganizationUnitResponse{ID: id}, nil
+}
+
+func (s *Server) getOrganizationUnitDetailed(body []byte) (interface{}, error) {
+	var req eva.GetOrganizationUnitDetailedRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	organizationUnit, ok := s.organizationUnits[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return organizationUnit.GetOrganizationUnitDetailedResponse, nil
+}
+
+// updateOrganizationUnit only updates the fields that are set, like EVA.
+func (s *Server) updateOrganizationUnit(body []byte) (interface{}, error) {
+	var req eva.UpdateOrganizationUnitRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	organizationUnit, ok := s.organizationUnits[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	current := &organizationUnit.GetOrganizationUnitDetailedResponse
+
+	updateString(&current.Name, req.Name)
+	updateString(&current.PhoneNumber, req.PhoneNumber)
+	updateString(&current.EmailAddress, req.EmailAddress)
+	updateString(&current.CostPriceCurrencyID, req.CostPriceCurrencyID)
+
+	if isSent(body, "BackendID") {
+		current.BackendID = req.BackendID
+	}
+
+	// These fields are always sent, so they are cleared when empty.
+	current.TimeZone = req.TimeZone
+	current.GLN = req.GLN
+	current.VatNumber = req.VatNumber
+	current.RegistrationNumber = req.RegistrationNumber
+	current.Subnet = req.Subnet
+
+	if req.Type != 0 {
+		current.Type = req.Type
+	}
+
+	if req.Latitude != 0 || req.Longitude != 0 {
+		current.Latitude = req.Latitude
+		current.Longitude = req.Longitude
+	}
+
+	if req.Address != nil {
+		current.Address = req.Address
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deleteOrganizationUnit(body []byte) (interface{}, error) {
+	var req eva.DeleteOrganizationUnitRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.organizationUnits[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.organizationUnits, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) moveOrganizationUnit(body []byte) (interface{}, error) {
+	var req eva.MoveOrganizationUnitRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	organizationUnit, ok := s.organizationUnits[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	organizationUnit.ParentID = req.ParentID
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) getOrganizationUnitOpeningHours(body []byte) (interface{}, error) {
+	var req eva.GetOrganizationUnitOpeningHoursRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	organizationUnit, ok := s.organizationUnits[req.OrganizationUnitID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return organizationUnit.openingHours, nil
+}
+
+func (s *Server) setOrganizationUnitOpeningHours(body []byte) (interface{}, error) {
+	var req eva.SetOrganizationUnitOpeningHoursRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	organizationUnit, ok := s.organizationUnits[req.OrganizationUnitID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	organizationUnit.openingHours = eva.GetOrganizationUnitOpeningHoursResponse{
+		OpeningHours: req.OpeningHours,
+		Exceptions:   req.Exceptions,
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) createOrganizationUnitSet(body []byte) (interface{}, error) {
+	var req eva.CreateOrganizationUnitSetRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	id := s.nextID()
+
+	s.organizationUnitSets[id] = &eva.GetOrganizationUnitSetResponse{
+		ID:                  id,
+		Name:                req.Name,
+		Description:         req.Description,
+		OrganizationUnitIDs: req.OrganizationUnitIDs,
+		Filter:              req.Filter,
+	}
+
+	return eva.CreateOrganizationUnitSetResponse{ID: id}, nil
+}
+
+func (s *Server) getOrganizationUnitSet(body []byte) (interface{}, error) {
+	var req eva.GetOrganizationUnitSetRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	organizationUnitSet, ok := s.organizationUnitSets[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return organizationUnitSet, nil
+}
+
+func (s *Server) updateOrganizationUnitSet(body []byte) (interface{}, error) {
+	var req eva.UpdateOrganizationUnitSetRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.organizationUnitSets[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	s.organizationUnitSets[req.ID] = &eva.GetOrganizationUnitSetResponse{
+		ID:                  req.ID,
+		Name:                req.Name,
+		Description:         req.Description,
+		OrganizationUnitIDs: req.OrganizationUnitIDs,
+		Filter:              req.Filter,
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deleteOrganizationUnitSet(body []byte) (interface{}, error) {
+	var req eva.DeleteOrganizationUnitSetRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.organizationUnitSets[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.organizationUnitSets, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+// updateString updates a field that is left out of update requests when it is not set.
+func updateString(current *string, value string) {
+	if value != "" {
+		*current = value
+	}
+}
@@ -0,0 +1,205 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+func (s *Server) registerRoleHandlers() {
+	s.handle("/api/core/management/CreateRole", s.createRole)
+	s.handle("/api/core/management/GetRole", s.getRole)
+	s.handle("/api/core/management/ListRoles", s.listRoles)
+	s.handle("/api/core/management/UpdateRole", s.updateRole)
+	s.handle("/api/core/management/DeleteRole", s.deleteRole)
+	s.handle("/api/core/management/AttachFunctionalitiesToRole", s.attachFunctionalitiesToRole)
+	s.handle("/api/core/management/DetachFunctionalitiesFromRole", s.detachFunctionalitiesFromRole)
+	s.handle("/api/core/management/GetUserRoles", s.getUserRoles)
+	s.handle("/api/core/management/SetUserRoles", s.setUserRoles)
+}
+
+func (s *Server) createRole(body []byte) (interface{}, error) {
+	var req eva.CreateRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	id := s.nextID()
+
+	s.roles[id] = &eva.Role{
+		ID:                    id,
+		Name:                  req.Name,
+		UserType:              req.UserType,
+		Code:                  req.Code,
+		ScopedFunctionalities: []eva.RoleFunctionality{},
+	}
+
+	return eva.CreateRoleResponse{ID: id}, nil
+}
+
+func (s *Server) getRole(body []byte) (interface{}, error) {
+	var req eva.GetRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	role, ok := s.roles[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return eva.GetRoleResponse{Result: *role}, nil
+}
+
+func (s *Server) listRoles(body []byte) (interface{}, error) {
+	roles := []eva.Role{}
+
+	for _, id := range sortedIDs(s.roles) {
+		roles = append(roles, *s.roles[id])
+	}
+
+	return eva.ListRolesResponse{Result: roles}, nil
+}
+
+func (s *Server) updateRole(body []byte) (interface{}, error) {
+	var req eva.UpdateRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	role, ok := s.roles[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	updateString(&role.Name, req.Name)
+	updateString(&role.Code, req.Code)
+
+	if req.UserType != 0 {
+		role.UserType = req.UserType
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deleteRole(body []byte) (interface{}, error) {
+	var req eva.DeleteRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.roles[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.roles, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) attachFunctionalitiesToRole(body []byte) (interface{}, error) {
+	var req eva.AttachFunctionalitiesToRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	role, ok := s.roles[req.RoleID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	for _, functionality := range req.ScopedFunctionalities {
+		role.ScopedFunctionalities = append(removeFunctionality(role.ScopedFunctionalities, functionality), functionality)
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) detachFunctionalitiesFromRole(body []byte) (interface{}, error) {
+	var req eva.DetachFunctionalitiesFromRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	role, ok := s.roles[req.RoleID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	for _, functionality := range req.ScopedFunctionalities {
+		role.ScopedFunctionalities = removeFunctionality(role.ScopedFunctionalities, functionality)
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) getUserRoles(body []byte) (interface{}, error) {
+	var req eva.GetUserRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.users[req.UserId]; !ok {
+		return nil, errNotFound
+	}
+
+	roles := s.userRoles[req.UserId]
+
+	if roles == nil {
+		roles = []eva.UserRole{}
+	}
+
+	return eva.GetUserRoleResponse{Roles: roles}, nil
+}
+
+func (s *Server) setUserRoles(body []byte) (interface{}, error) {
+	var req eva.SetUserRoleRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.users[req.UserId]; !ok {
+		return nil, errNotFound
+	}
+
+	var roles []eva.UserRole
+
+	for _, role := range req.Roles {
+		if _, ok := s.roles[role.RoleID]; !ok {
+			return nil, errNotFound
+		}
+
+		roles = append(roles, eva.UserRole{
+			RoleID:             role.RoleID,
+			OrganizationUnitID: role.OrganizationUnitID,
+			UserType:           role.UserType,
+		})
+	}
+
+	s.userRoles[req.UserId] = roles
+
+	return eva.EmptyResponse{}, nil
+}
+
+// removeFunctionality removes the functionality with the same name and scope.
+func removeFunctionality(functionalities []eva.RoleFunctionality, functionality eva.RoleFunctionality) []eva.RoleFunctionality {
+	result := []eva.RoleFunctionality{}
+
+	for _, f := range functionalities {
+		if f.Functionality != functionality.Functionality || f.Scope != functionality.Scope {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
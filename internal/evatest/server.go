@@ -0,0 +1,225 @@
+// Package evatest provides an in-memory fake of the EVA API, so the provider can be tested without an EVA tenant.
+//
+// The fake implements the endpoints used by the client in internal/eva, with just enough behaviour to create,
+// read, update and delete the entities the provider manages.
+package evatest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+const (
+	// Username and Password are the credentials the fake accepts on login.
+	Username = "admin"
+	Password = "admin"
+
+	// Token is the authentication token the fake returns on login, and accepts on all other requests.
+	Token = "evatest-token"
+)
+
+var errNotFound = errors.New("Entity not found.")
+
+// handler handles the request body of an endpoint, and returns the response body.
+type handler func(body []byte) (interface{}, error)
+
+// Server is a fake EVA API. All entities are kept in memory, and are lost when the server is closed.
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	lastID   int64
+	handlers map[string]handler
+	requests map[string]int
+
+	organizationUnits    map[int64]*organizationUnit
+	organizationUnitSets map[int64]*eva.GetOrganizationUnitSetResponse
+	roles                map[int64]*eva.Role
+	userRoles            map[int64][]eva.UserRole
+	settings             map[settingKey]string
+	messageTemplates     map[int64]*eva.GetMessageTemplateByIDResponse
+	recipes              map[int64]*recipe
+	openIDProviders      map[int64]*eva.GetOpenIDProviderResponse
+	users                map[int64]*eva.GetEmployeeResponse
+	customOrderStatuses  map[int64]*eva.CustomOrderStatus
+	orderLedgerTypes     map[int64]*eva.OrderLedgerType
+}
+
+// NewServer starts a fake EVA API. The server has to be closed when it is no longer used.
+func NewServer() *Server {
+	s := &Server{
+		organizationUnits:    map[int64]*organizationUnit{},
+		organizationUnitSets: map[int64]*eva.GetOrganizationUnitSetResponse{},
+		roles:                map[int64]*eva.Role{},
+		userRoles:            map[int64][]eva.UserRole{},
+		settings:             map[settingKey]string{},
+		messageTemplates:     map[int64]*eva.GetMessageTemplateByIDResponse{},
+		recipes:              map[int64]*recipe{},
+		openIDProviders:      map[int64]*eva.GetOpenIDProviderResponse{},
+		users:                map[int64]*eva.GetEmployeeResponse{},
+		customOrderStatuses:  map[int64]*eva.CustomOrderStatus{},
+		orderLedgerTypes:     map[int64]*eva.OrderLedgerType{},
+	}
+
+	s.handlers = map[string]handler{}
+	s.requests = map[string]int{}
+
+	s.registerOrganizationUnitHandlers()
+	s.registerRoleHandlers()
+	s.registerSettingHandlers()
+	s.registerStencilHandlers()
+	s.registerCookbookHandlers()
+	s.registerOpenIDProviderHandlers()
+	s.registerEmployeeHandlers()
+	s.registerOrderHandlers()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// NewClient returns a client of the fake EVA API that is logged in.
+func (s *Server) NewClient() *eva.Client {
+	client := eva.NewClient(s.URL)
+	client.SetAuthorizationHeader(Token)
+
+	return client
+}
+
+// Requests returns the number of requests the server handled at the path.
+func (s *Server) Requests(path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.requests[path]
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/core/Login" {
+		s.login(w, r)
+		return
+	}
+
+	handler, ok := s.handlers[r.URL.Path]
+
+	if !ok || r.Method != http.MethodPost {
+		writeError(w, http.StatusNotFound, "UnknownService", fmt.Sprintf("No service at %s %s.", r.Method, r.URL.Path))
+		return
+	}
+
+	if r.Header.Get("authorization") != Token {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "Invalid authentication token.")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	s.mutex.Lock()
+	s.requests[r.URL.Path]++
+	resp, err := handler(body)
+	s.mutex.Unlock()
+
+	if errors.Is(err, errNotFound) {
+		writeError(w, http.StatusNotFound, "EntityNotFound", err.Error())
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var credentials eva.LoginCredentials
+
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	if credentials.Username != Username || credentials.Password != Password {
+		writeError(w, http.StatusUnauthorized, "InvalidCredentials", "Invalid username or password.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, eva.LoginResponse{AuthenticationToken: Token})
+}
+
+// handle registers the handler of the endpoint at the path.
+func (s *Server) handle(path string, h handler) {
+	s.handlers[path] = h
+}
+
+// nextID returns a new ID, IDs are unique across all entities.
+func (s *Server) nextID() int64 {
+	s.lastID++
+
+	return s.lastID
+}
+
+// sortedIDs returns the IDs of a map of entities by ID in ascending order, so lists are returned in a stable order.
+func sortedIDs(entities interface{}) []int64 {
+	var ids []int64
+
+	for _, key := range reflect.ValueOf(entities).MapKeys() {
+		ids = append(ids, key.Int())
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func decode(body []byte, req interface{}) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, req)
+}
+
+// isSent returns whether the field is in the body, EVA leaves fields which are not sent unchanged.
+func isSent(body []byte, field string) bool {
+	var fields map[string]json.RawMessage
+
+	if json.Unmarshal(body, &fields) != nil {
+		return false
+	}
+
+	_, ok := fields[field]
+
+	return ok
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errorType string, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"Error": map[string]interface{}{
+			"Type":    errorType,
+			"Message": message,
+		},
+	})
+}
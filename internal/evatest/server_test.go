@@ -0,0 +1,171 @@
+package evatest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+func TestLogin(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name        string
+		credentials eva.LoginCredentials
+		expectError bool
+	}{
+		{
+			name:        "valid credentials",
+			credentials: eva.LoginCredentials{Username: Username, Password: Password},
+			expectError: false,
+		},
+		{
+			name:        "invalid credentials",
+			credentials: eva.LoginCredentials{Username: Username, Password: "wrong"},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := eva.NewClient(server.URL).Login(context.Background(), testCase.credentials)
+
+			if (err != nil) != testCase.expectError {
+				t.Errorf("expected error: %t, got %v", testCase.expectError, err)
+			}
+		})
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	if _, err := eva.NewClient(server.URL).ListCustomOrderStatus(context.Background()); err == nil {
+		t.Error("expected error without authentication token")
+	}
+}
+
+func TestCustomOrderStatus(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	created, err := client.CreateCustomOrderStatus(ctx, eva.CreateCustomOrderStatusRequest{Name: "pending", Description: "pending order"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.UpdateCustomOrderStatus(ctx, eva.UpdateCustomOrderStatusRequest{ID: created.ID, Name: "completed", Description: "completed order"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	list, err := client.ListCustomOrderStatus(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(list.Result) != 1 || list.Result[0].ID != created.ID || list.Result[0].Name != "completed" {
+		t.Errorf("expected updated custom order status %d, got %+v", created.ID, list.Result)
+	}
+
+	if _, err := client.DeleteCustomOrderStatus(ctx, eva.DeleteCustomOrderStatusRequest{ID: created.ID}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.DeleteCustomOrderStatus(ctx, eva.DeleteCustomOrderStatusRequest{ID: created.ID}); err == nil {
+		t.Error("expected error deleting a deleted custom order status")
+	}
+}
+
+func TestOpenIDProviderNotFound(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	_, err := server.NewClient().GetOpenIDProvider(context.Background(), eva.GetOpenIDProviderRequest{ID: 1})
+
+	if !errors.Is(err, eva.ErrNotFound) {
+		t.Errorf("expected %s, got %v", eva.ErrNotFound, err)
+	}
+}
+
+func TestSetPrimaryOpenIDProvider(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	var ids []int64
+
+	for _, name := range []string{"first", "second"} {
+		created, err := client.CreateOpenIDProvider(ctx, eva.CreateOpenIDProviderRequest{Name: name, ClientSecret: "secret"})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		ids = append(ids, created.ID)
+	}
+
+	for _, primaryID := range ids {
+		if _, err := client.SetPrimaryOpenIDProvider(ctx, eva.SetPrimaryOpenIDProviderRequest{ID: primaryID}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		for _, id := range ids {
+			openIDProvider, err := client.GetOpenIDProvider(ctx, eva.GetOpenIDProviderRequest{ID: id})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if openIDProvider.Primary != (id == primaryID) {
+				t.Errorf("expected OpenID provider %d primary: %t, got %t", id, id == primaryID, openIDProvider.Primary)
+			}
+		}
+	}
+}
+
+func TestCreateAccountingRecipe(t *testing.T) {
+	testCases := []struct {
+		name        string
+		recipe      string
+		expectError bool
+	}{
+		{
+			name:        "valid recipe",
+			recipe:      "rule \"invoices\"\n  on OrderInvoiced\n  book Invoice.TotalAmount from 8000 to 1300\nend\n",
+			expectError: false,
+		},
+		{
+			name:        "unterminated rule",
+			recipe:      "rule \"invoices\"\n  on OrderInvoiced\n",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := NewServer()
+			defer server.Close()
+
+			_, err := server.NewClient().CreateAccountingRecipe(context.Background(), eva.CreateAccountingRecipeRequest{Name: "test", Recipe: testCase.recipe})
+
+			var recipeErrors eva.RecipeErrors
+
+			if errors.As(err, &recipeErrors) != testCase.expectError {
+				t.Errorf("expected recipe errors: %t, got %v", testCase.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+// settingKey identifies a setting, settings can be set globally and per organization unit (set).
+type settingKey struct {
+	key                   string
+	organizationUnitID    int64
+	organizationUnitSetID int64
+}
+
+func (s *Server) registerSettingHandlers() {
+	s.handle("/api/core/management/GetSetting", s.getSetting)
+	s.handle("/api/core/management/SetSetting", s.setSetting)
+	s.handle("/api/core/management/UnsetSetting", s.unsetSetting)
+}
+
+func (s *Server) getSetting(body []byte) (interface{}, error) {
+	var req eva.GetSettingRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.organizationUnits[req.OrganizationUnitID]; req.OrganizationUnitID != 0 && !ok {
+		return nil, errNotFound
+	}
+
+	return eva.GetSettingResponse{
+		Value: s.settings[settingKey{req.Key, req.OrganizationUnitID, req.OrganizationUnitSetID}],
+	}, nil
+}
+
+func (s *Server) setSetting(body []byte) (interface{}, error) {
+	var req eva.SetSettingsRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	s.settings[settingKey{req.Key, req.OrganizationUnitID, req.OrganizationUnitSetID}] = req.Value
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) unsetSetting(body []byte) (interface{}, error) {
+	var req eva.UnsetSettingsRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	delete(s.settings, settingKey{req.Key, req.OrganizationUnitID, req.OrganizationUnitSetID})
+
+	return eva.EmptyResponse{}, nil
+}
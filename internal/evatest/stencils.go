@@ -0,0 +1,136 @@
+package evatest
+
+import (
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+func (s *Server) registerStencilHandlers() {
+	s.handle("/api/core/management/CreateMessageTemplate", s.createMessageTemplate)
+	s.handle("/api/core/management/GetMessageTemplateByID", s.getMessageTemplateByID)
+	s.handle("/api/core/management/UpdateMessageTemplate", s.updateMessageTemplate)
+	s.handle("/api/core/management/DeleteMessageTemplate", s.deleteMessageTemplate)
+	s.handle("/api/core/management/ListMessageTemplates", s.listMessageTemplates)
+}
+
+func (s *Server) createMessageTemplate(body []byte) (interface{}, error) {
+	var req eva.CreateMessageTemplateRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	id := s.nextID()
+
+	s.messageTemplates[id] = &eva.GetMessageTemplateByIDResponse{
+		ID:                    id,
+		Name:                  req.Name,
+		OrganizationUnitID:    req.OrganizationUnitID,
+		OrganizationUnitSetID: req.OrganizationUnitSetID,
+		LanguageID:            req.LanguageID,
+		CountryID:             req.CountryID,
+		Header:                req.Header,
+		Template:              req.Template,
+		Footer:                req.Footer,
+		Helpers:               req.Helpers,
+		Type:                  req.Type,
+		Layout:                req.Layout,
+		Destination:           req.Destination,
+		PaperProperties:       req.PaperProperties,
+		IsDisabled:            req.IsDisabled,
+	}
+
+	return eva.CreateMessageTemplateResponse{ID: id}, nil
+}
+
+func (s *Server) getMessageTemplateByID(body []byte) (interface{}, error) {
+	var req eva.GetMessageTemplateByIDRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	messageTemplate, ok := s.messageTemplates[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return messageTemplate, nil
+}
+
+// updateMessageTemplate replaces the message template, the type of a message template can't be changed.
+func (s *Server) updateMessageTemplate(body []byte) (interface{}, error) {
+	var req eva.UpdateMessageTemplateRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	messageTemplate, ok := s.messageTemplates[req.ID]
+
+	if !ok {
+		return nil, errNotFound
+	}
+
+	s.messageTemplates[req.ID] = &eva.GetMessageTemplateByIDResponse{
+		ID:                    req.ID,
+		Name:                  req.Name,
+		OrganizationUnitID:    req.OrganizationUnitID,
+		OrganizationUnitSetID: req.OrganizationUnitSetID,
+		LanguageID:            req.LanguageID,
+		CountryID:             req.CountryID,
+		Header:                req.Header,
+		Template:              req.Template,
+		Footer:                req.Footer,
+		Helpers:               req.Helpers,
+		Type:                  messageTemplate.Type,
+		Layout:                req.Layout,
+		Destination:           req.Destination,
+		PaperProperties:       req.PaperProperties,
+		IsDisabled:            req.IsDisabled,
+	}
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) deleteMessageTemplate(body []byte) (interface{}, error) {
+	var req eva.DeleteMessageTemplateRequesst
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.messageTemplates[req.ID]; !ok {
+		return nil, errNotFound
+	}
+
+	delete(s.messageTemplates, req.ID)
+
+	return eva.EmptyResponse{}, nil
+}
+
+func (s *Server) listMessageTemplates(body []byte) (interface{}, error) {
+	var req eva.ListMessageTemplatesRequest
+
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+
+	messageTemplates := []eva.MessageTemplate{}
+
+	for _, id := range sortedIDs(s.messageTemplates) {
+		messageTemplate := s.messageTemplates[id]
+
+		if req.Type != 0 && messageTemplate.Type != req.Type {
+			continue
+		}
+
+		messageTemplates = append(messageTemplates, eva.MessageTemplate{
+			ID:   messageTemplate.ID,
+			Name: messageTemplate.Name,
+			Type: messageTemplate.Type,
+		})
+	}
+
+	return eva.ListMessageTemplatesResponse{Result: messageTemplates}, nil
+}
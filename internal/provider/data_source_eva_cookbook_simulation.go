@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+type cookbookSimulationType struct{}
+
+func (t cookbookSimulationType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Simulates a cookbook recipe against sample financial events in EVA, and returns the bookings the recipe makes without booking them.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "SHA-256 of the recipe and the events.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"recipe": {
+				MarkdownDescription: "Recipe to simulate, e.g. the `recipe` of an `eva_cookbook`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"events": {
+				MarkdownDescription: "Sample financial events to simulate the recipe against.",
+				Required:            true,
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"type": {
+							MarkdownDescription: "Type of the event, e.g. `OrderInvoiced`.",
+							Required:            true,
+							Type:                types.StringType,
+						},
+						"data": {
+							MarkdownDescription: "Data of the event as JSON, e.g. `jsonencode({ Invoice = { TotalAmount = 100 } })`.",
+							Required:            true,
+							Type:                types.StringType,
+						},
+					},
+					tfsdk.ListNestedAttributesOptions{},
+				),
+			},
+			"bookings": {
+				MarkdownDescription: "Bookings the recipe makes for the events.",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"event_index": {
+							MarkdownDescription: "Index in `events` of the event that is booked.",
+							Computed:            true,
+							Type:                types.Int64Type,
+						},
+						"rule": {
+							MarkdownDescription: "Name of the rule that made the booking.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"debit": {
+							MarkdownDescription: "Ledger account that is debited.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"credit": {
+							MarkdownDescription: "Ledger account that is credited.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"amount": {
+							MarkdownDescription: "Amount that is booked, as a decimal number in a string so it is exactly the amount EVA returned, e.g. `\"100.10\"`.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"description": {
+							MarkdownDescription: "Description of the booking.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+					},
+					tfsdk.ListNestedAttributesOptions{},
+				),
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": dataSourceTimeoutsBlock(),
+		},
+	}, nil
+}
+
+func (t cookbookSimulationType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return cookbookSimulation{
+		provider: provider,
+	}, diags
+}
+
+type cookbookSimulationEventData struct {
+	Type types.String `tfsdk:"type"`
+	Data types.String `tfsdk:"data"`
+}
+
+type cookbookSimulationBookingData struct {
+	EventIndex  types.Int64  `tfsdk:"event_index"`
+	Rule        types.String `tfsdk:"rule"`
+	Debit       types.String `tfsdk:"debit"`
+	Credit      types.String `tfsdk:"credit"`
+	Amount      types.String `tfsdk:"amount"`
+	Description types.String `tfsdk:"description"`
+}
+
+type cookbookSimulationData struct {
+	ID       types.String                    `tfsdk:"id"`
+	Recipe   types.String                    `tfsdk:"recipe"`
+	Events   []cookbookSimulationEventData   `tfsdk:"events"`
+	Bookings []cookbookSimulationBookingData `tfsdk:"bookings"`
+	Timeouts dataSourceTimeouts              `tfsdk:"timeouts"`
+}
+
+type cookbookSimulation struct {
+	provider provider
+}
+
+func (d cookbookSimulation) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data cookbookSimulationData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var events []eva.SimulatedEvent
+
+	for i, event := range data.Events {
+		if !json.Valid([]byte(event.Data.Value)) {
+			resp.Diagnostics.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName("events").WithElementKeyInt(i).WithAttributeName("data"),
+				"Invalid event data.",
+				fmt.Sprintf("The data of event %d is not valid JSON.", i),
+			)
+			continue
+		}
+
+		events = append(events, eva.SimulatedEvent{
+			Type: event.Type.Value,
+			Data: json.RawMessage(event.Data.Value),
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
+	simulateRequest := eva.SimulateAccountingRecipeRequest{
+		Recipe: data.Recipe.Value,
+		Events: events,
+	}
+
+	client_resp, err := d.provider.evaClient.SimulateAccountingRecipe(ctx, simulateRequest)
+
+	if err != nil {
+		if !addRecipeErrors(&resp.Diagnostics, err) {
+			resp.Diagnostics.AddError("Simulating cookbook failed.", fmt.Sprintf("Unable to simulate cookbook, got error: %s", err))
+		}
+		return
+	}
+
+	// The bookings depend on the events as well, so the ID changes when either of them changes.
+	request, _ := json.Marshal(simulateRequest)
+
+	data.ID = types.String{Value: sha256Hex(string(request))}
+	data.Bookings = []cookbookSimulationBookingData{}
+
+	for _, booking := range client_resp.Bookings {
+		data.Bookings = append(data.Bookings, cookbookSimulationBookingData{
+			EventIndex:  types.Int64{Value: booking.EventIndex},
+			Rule:        stringValueOrNull(booking.Rule),
+			Debit:       types.String{Value: booking.DebitAccount},
+			Credit:      types.String{Value: booking.CreditAccount},
+			Amount:      types.String{Value: booking.Amount.String()},
+			Description: stringValueOrNull(booking.Description),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
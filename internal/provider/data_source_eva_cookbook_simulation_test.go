@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestCookbookSimulationRead(t *testing.T) {
+	server := evatest.NewServer()
+	defer server.Close()
+
+	d := cookbookSimulation{provider: testProvider(server)}
+
+	recipe := "rule \"invoices\"\n  on OrderInvoiced\n  when Invoice.TotalAmount > 0\n  book Invoice.TotalAmount from 8000 to 1300 as \"Invoiced orders\"\nend\n"
+
+	data, diags := testReadCookbookSimulation(d, recipe,
+		cookbookSimulationEventData{Type: types.String{Value: "OrderInvoiced"}, Data: types.String{Value: `{"Invoice": {"TotalAmount": 12345678901234567.89}}`}},
+		cookbookSimulationEventData{Type: types.String{Value: "OrderInvoiced"}, Data: types.String{Value: `{"Invoice": {"TotalAmount": 0}}`}},
+		cookbookSimulationEventData{Type: types.String{Value: "OrderInvoiced"}, Data: types.String{Value: `{"Invoice": {"TotalAmount": 0.10}}`}},
+	)
+
+	for _, d := range diags {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	expected := []cookbookSimulationBookingData{
+		{
+			EventIndex:  types.Int64{Value: 0},
+			Rule:        types.String{Value: "invoices"},
+			Debit:       types.String{Value: "1300"},
+			Credit:      types.String{Value: "8000"},
+			Amount:      types.String{Value: "12345678901234567.89"},
+			Description: types.String{Value: "Invoiced orders"},
+		},
+		{
+			EventIndex:  types.Int64{Value: 2},
+			Rule:        types.String{Value: "invoices"},
+			Debit:       types.String{Value: "1300"},
+			Credit:      types.String{Value: "8000"},
+			Amount:      types.String{Value: "0.10"},
+			Description: types.String{Value: "Invoiced orders"},
+		},
+	}
+
+	if len(data.Bookings) != len(expected) {
+		t.Fatalf("expected %d bookings, got %+v", len(expected), data.Bookings)
+	}
+
+	for i, booking := range data.Bookings {
+		if booking != expected[i] {
+			t.Errorf("expected booking %+v, got %+v", expected[i], booking)
+		}
+	}
+
+	// The same recipe simulated against other events has another ID.
+	other, diags := testReadCookbookSimulation(d, recipe,
+		cookbookSimulationEventData{Type: types.String{Value: "OrderInvoiced"}, Data: types.String{Value: `{"Invoice": {"TotalAmount": 1}}`}},
+	)
+
+	for _, d := range diags {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	if data.ID.Value == other.ID.Value {
+		t.Errorf("expected simulations of other events to have another ID, got %s", data.ID.Value)
+	}
+}
+
+func TestCookbookSimulationReadInvalidEventData(t *testing.T) {
+	server := evatest.NewServer()
+	defer server.Close()
+
+	d := cookbookSimulation{provider: testProvider(server)}
+
+	_, diags := testReadCookbookSimulation(d, "",
+		cookbookSimulationEventData{Type: types.String{Value: "OrderInvoiced"}, Data: types.String{Value: `{"Invoice": `}},
+	)
+
+	if len(diags) != 1 || diags[0].Summary() != "Invalid event data." {
+		t.Errorf("expected the event data to be invalid, got %v", diags)
+	}
+}
+
+func TestCookbookSimulationReadTimeout(t *testing.T) {
+	done := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	d := cookbookSimulation{provider: provider{evaClient: *eva.NewClient(server.URL), configured: true}}
+
+	start := time.Now()
+
+	_, diags := testReadCookbookSimulationWithTimeouts(d, "", dataSourceTimeouts{{Read: types.String{Value: "50ms"}}},
+		cookbookSimulationEventData{Type: types.String{Value: "OrderInvoiced"}, Data: types.String{Value: `{}`}},
+	)
+
+	if !diags.HasError() {
+		t.Errorf("expected the simulation to time out, got %v", diags)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the simulation to stop after the read timeout, took %s", elapsed)
+	}
+}
+
+func testReadCookbookSimulation(d cookbookSimulation, recipe string, events ...cookbookSimulationEventData) (cookbookSimulationData, diag.Diagnostics) {
+	return testReadCookbookSimulationWithTimeouts(d, recipe, dataSourceTimeouts{}, events...)
+}
+
+func testReadCookbookSimulationWithTimeouts(d cookbookSimulation, recipe string, timeouts dataSourceTimeouts, events ...cookbookSimulationEventData) (cookbookSimulationData, diag.Diagnostics) {
+	ctx := context.Background()
+	schema, _ := cookbookSimulationType{}.GetSchema(ctx)
+
+	config := tfsdk.State{Schema: schema}
+	config.Set(ctx, &cookbookSimulationData{
+		ID:       types.String{Null: true},
+		Recipe:   types.String{Value: recipe},
+		Events:   events,
+		Bookings: nil,
+		Timeouts: timeouts,
+	})
+
+	resp := tfsdk.ReadDataSourceResponse{State: tfsdk.State{Schema: schema}}
+	d.Read(ctx, tfsdk.ReadDataSourceRequest{Config: tfsdk.Config{Schema: schema, Raw: config.Raw}}, &resp)
+
+	var data cookbookSimulationData
+
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+
+	return data, resp.Diagnostics
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aymerick/raymond"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type stencilPreviewType struct{}
+
+func (t stencilPreviewType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Renders a stencil template against a sample model, without sending it to EVA. " +
+			"The template is rendered with a Go implementation of Handlebars 3, so the JavaScript helpers of a stencil and the syntax of newer versions, " +
+			"like partial blocks and inline partials, are not available. Data sources are read during every plan, so the preview doesn't write any files.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "SHA-256 of the rendered HTML.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"template": {
+				MarkdownDescription: "Template to render. Conflicts with `template_file`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					handlebarsValidator{},
+				},
+			},
+			"template_file": {
+				MarkdownDescription: "Path of a file with the template to render. Conflicts with `template`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"partials": {
+				MarkdownDescription: "Templates of the partials the template refers to, by name.",
+				Optional:            true,
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+			},
+			"model": {
+				MarkdownDescription: "Sample model to render the template against as JSON, e.g. `jsonencode({ Order = { ID = 1 } })`. Defaults to an empty object.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"html": {
+				MarkdownDescription: "The rendered HTML. To review it in a browser, write it to a file with the `local_file` resource of the `hashicorp/local` provider.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t stencilPreviewType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return stencilPreview{
+		provider: provider,
+	}, diags
+}
+
+type stencilPreviewData struct {
+	ID           types.String      `tfsdk:"id"`
+	Template     types.String      `tfsdk:"template"`
+	TemplateFile types.String      `tfsdk:"template_file"`
+	Partials     map[string]string `tfsdk:"partials"`
+	Model        types.String      `tfsdk:"model"`
+	HTML         types.String      `tfsdk:"html"`
+}
+
+type stencilPreview struct {
+	provider provider
+}
+
+func (d stencilPreview) ValidateConfig(ctx context.Context, req tfsdk.ValidateDataSourceConfigRequest, resp *tfsdk.ValidateDataSourceConfigResponse) {
+	resp.Diagnostics.Append(validateConflictingAttributes(req.Config, true, "template", "template_file")...)
+}
+
+func (d stencilPreview) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data stencilPreviewData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := data.Template.Value
+	sourcePath := tftypes.NewAttributePath().WithAttributeName("template")
+
+	if !data.TemplateFile.Null {
+		sourcePath = tftypes.NewAttributePath().WithAttributeName("template_file")
+
+		file, err := os.ReadFile(data.TemplateFile.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(sourcePath, "Reading template file failed.", fmt.Sprintf("Unable to read %s, got error: %s", data.TemplateFile.Value, err))
+			return
+		}
+
+		source = string(file)
+	}
+
+	var model interface{} = map[string]interface{}{}
+
+	if !data.Model.Null && data.Model.Value != "" {
+		if err := json.Unmarshal([]byte(data.Model.Value), &model); err != nil {
+			resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("model"), "Invalid model.", fmt.Sprintf("Unable to parse the model as JSON, got error: %s", err))
+			return
+		}
+	}
+
+	template, err := raymond.Parse(source)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(sourcePath, "Invalid template.", fmt.Sprintf("Unable to parse the Handlebars template, got error: %s", err))
+		return
+	}
+
+	for name, partial := range data.Partials {
+		if _, err := parseHandlebars(partial); err != nil {
+			resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("partials").WithElementKeyString(name), "Invalid partial.", fmt.Sprintf("Unable to parse the Handlebars template, got error: %s", err))
+			return
+		}
+	}
+
+	template.RegisterPartials(data.Partials)
+
+	html, err := template.Exec(model)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Rendering template failed.", fmt.Sprintf("Unable to render the template, got error: %s", err))
+		return
+	}
+
+	data.ID = types.String{Value: sha256Hex(html)}
+	data.HTML = types.String{Value: html}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
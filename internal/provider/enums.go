@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// enum maps the human readable names of the values of an EVA enum to their numeric values.
+// Names are matched case insensitively, and the numeric values are accepted as well,
+// so configurations written before an attribute accepted names keep working.
+type enum map[string]int64
+
+// names returns the names of the enum ordered by their values.
+func (e enum) names() []string {
+	var names []string
+
+	for name := range e {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return e[names[i]] < e[names[j]]
+	})
+
+	return names
+}
+
+// toValue returns the numeric value of a name or a number.
+func (e enum) toValue(name string) (int64, bool) {
+	for enumName, value := range e {
+		if strings.EqualFold(enumName, name) {
+			return value, true
+		}
+	}
+
+	value, err := strconv.ParseInt(name, 10, 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	for _, enumValue := range e {
+		if enumValue == value {
+			return value, true
+		}
+	}
+
+	return 0, false
+}
+
+// toName returns the name of a numeric value, or the number itself when the value has no name.
+func (e enum) toName(value int64) string {
+	for name, enumValue := range e {
+		if enumValue == value {
+			return name
+		}
+	}
+
+	return strconv.FormatInt(value, 10)
+}
+
+// valueOf returns the numeric value of an attribute, zero when it is not set.
+func (e enum) valueOf(attribute types.String) int64 {
+	if attribute.Null || attribute.Unknown {
+		return 0
+	}
+
+	value, _ := e.toValue(attribute.Value)
+
+	return value
+}
+
+// valuePointer returns the numeric value of an attribute, nil when it is not set.
+func (e enum) valuePointer(attribute types.String) *int64 {
+	if attribute.Null || attribute.Unknown {
+		return nil
+	}
+
+	value := e.valueOf(attribute)
+
+	return &value
+}
+
+// valueOrNull returns the attribute for a numeric value returned by EVA. The current value of the attribute is kept
+// when it refers to the same value, so a configuration can keep using either the name or the number.
+func (e enum) valueOrNull(current types.String, value int64) types.String {
+	if currentValue, ok := e.toValue(current.Value); !current.Null && !current.Unknown && ok && currentValue == value {
+		return current
+	}
+
+	if value == 0 {
+		return types.String{Null: true}
+	}
+
+	return types.String{Value: e.toName(value)}
+}
+
+// pointerValueOrNull returns the attribute for a numeric value EVA can leave out, like valueOrNull.
+func (e enum) pointerValueOrNull(current types.String, value *int64) types.String {
+	if value == nil {
+		return types.String{Null: true}
+	}
+
+	return e.valueOrNull(current, *value)
+}
+
+// flagsValue returns the numeric value of a set of names of a flags enum, e.g. [mail, pdf] is 1 | 4.
+func (e enum) flagsValue(names []string) int64 {
+	var value int64
+
+	for _, name := range names {
+		flag, _ := e.toValue(name)
+		value |= flag
+	}
+
+	return value
+}
+
+// flagsNames returns the names of the flags in a numeric value of a flags enum.
+func (e enum) flagsNames(value int64) []string {
+	var names []string
+
+	for _, name := range e.names() {
+		if value&e[name] != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// markdownDescription documents the names and numeric values of the enum.
+func (e enum) markdownDescription() string {
+	var values []string
+
+	for _, name := range e.names() {
+		values = append(values, fmt.Sprintf("`%s` (%d)", name, e[name]))
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aymerick/raymond/ast"
+	"github.com/aymerick/raymond/parser"
+)
+
+// parseHandlebars parses a Handlebars template and returns the names of the partials it references.
+// Partials with a dynamic name, e.g. {{> (lookup . 'name') }}, can't be resolved before rendering and are left out.
+//
+// The parser supports the syntax of Handlebars 3. EVA renders with Handlebars 4, so templates using partial blocks
+// ({{#> layout}}), inline partials ({{#*inline "name"}}) or other decorators fail to parse while EVA accepts them.
+// Parse errors are therefore only reported as warnings, see handlebarsParseWarning.
+func parseHandlebars(source string) ([]string, error) {
+	program, err := parser.Parse(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	visitor := partialsVisitor{partials: map[string]bool{}}
+	program.Accept(&visitor)
+
+	var partials []string
+
+	for name := range visitor.partials {
+		partials = append(partials, name)
+	}
+
+	sort.Strings(partials)
+
+	return partials, nil
+}
+
+// handlebarsParseWarning returns the detail of the warning for a template that could not be parsed.
+func handlebarsParseWarning(err error) string {
+	return fmt.Sprintf("Unable to parse the Handlebars template, got error: %s\n\n"+
+		"Only the syntax of Handlebars 3 can be validated, templates using newer syntax like partial blocks ({{#> layout}}) "+
+		"or inline partials ({{#*inline \"name\"}}) are sent to EVA as they are. Partials referenced by the template are not checked.", err)
+}
+
+// partialsVisitor collects the names of the partials in a Handlebars template.
+// Partials can only be statements, so expressions and literals are not visited.
+type partialsVisitor struct {
+	partials map[string]bool
+}
+
+func (v *partialsVisitor) VisitProgram(node *ast.Program) interface{} {
+	for _, statement := range node.Body {
+		statement.Accept(v)
+	}
+
+	return nil
+}
+
+func (v *partialsVisitor) VisitBlock(node *ast.BlockStatement) interface{} {
+	if node.Program != nil {
+		node.Program.Accept(v)
+	}
+
+	if node.Inverse != nil {
+		node.Inverse.Accept(v)
+	}
+
+	return nil
+}
+
+func (v *partialsVisitor) VisitPartial(node *ast.PartialStatement) interface{} {
+	switch name := node.Name.(type) {
+	case *ast.PathExpression:
+		v.partials[name.Original] = true
+	case *ast.StringLiteral:
+		v.partials[name.Value] = true
+	case *ast.NumberLiteral:
+		v.partials[name.Original] = true
+	}
+
+	return nil
+}
+
+func (v *partialsVisitor) VisitMustache(node *ast.MustacheStatement) interface{}  { return nil }
+func (v *partialsVisitor) VisitContent(node *ast.ContentStatement) interface{}    { return nil }
+func (v *partialsVisitor) VisitComment(node *ast.CommentStatement) interface{}    { return nil }
+func (v *partialsVisitor) VisitExpression(node *ast.Expression) interface{}       { return nil }
+func (v *partialsVisitor) VisitSubExpression(node *ast.SubExpression) interface{} { return nil }
+func (v *partialsVisitor) VisitPath(node *ast.PathExpression) interface{}         { return nil }
+func (v *partialsVisitor) VisitString(node *ast.StringLiteral) interface{}        { return nil }
+func (v *partialsVisitor) VisitBoolean(node *ast.BooleanLiteral) interface{}      { return nil }
+func (v *partialsVisitor) VisitNumber(node *ast.NumberLiteral) interface{}        { return nil }
+func (v *partialsVisitor) VisitHash(node *ast.Hash) interface{}                   { return nil }
+func (v *partialsVisitor) VisitHashPair(node *ast.HashPair) interface{}           { return nil }
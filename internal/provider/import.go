@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const importByNamePrefix = "name:"
+
+// importStateByIDOrName imports a resource by its numeric ID, or by its name when the import ID is `name:<name>`.
+// EVA only shows the names in its admin UI, so these are easier to find than the IDs.
+func importStateByIDOrName(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse, kind string, findIDByName func(ctx context.Context, name string) (int64, bool, error)) {
+	var id int64
+
+	if strings.HasPrefix(req.ID, importByNamePrefix) {
+		name := strings.TrimPrefix(req.ID, importByNamePrefix)
+
+		foundID, found, err := findIDByName(ctx, name)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Importing "+kind+" failed.", fmt.Sprintf("Unable to find %s by name, got error: %s", kind, err))
+			return
+		}
+
+		if !found {
+			resp.Diagnostics.AddError("Importing "+kind+" failed.", fmt.Sprintf("No %s is named %q.", kind, name))
+			return
+		}
+
+		id = foundID
+	} else {
+		parsedID, err := strconv.ParseInt(req.ID, 10, 64)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID.", fmt.Sprintf("Expected the ID of the %s or %s<name>, got %q.", kind, importByNamePrefix, req.ID))
+			return
+		}
+
+		id = parsedID
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)...)
+}
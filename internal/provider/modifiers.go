@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -43,3 +44,78 @@ func (m boolDefaultModifier) Modify(ctx context.Context, req tfsdk.ModifyAttribu
 	}
 
 }
+
+type int64DefaultModifier struct {
+	Default int64
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (m int64DefaultModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("If value is not configured, defaults to %d", m.Default)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (m int64DefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("If value is not configured, defaults to `%d`", m.Default)
+}
+
+// Modify runs the logic of the plan modifier.
+func (m int64DefaultModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	var value types.Int64
+	diags := tfsdk.ValueAs(ctx, req.AttributePlan, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if value.Unknown {
+		resp.AttributePlan = types.Int64{Value: m.Default}
+	}
+}
+
+// fileHashModifier plans the SHA-256 of the contents of the file configured in the sibling File attribute,
+// so a change to the contents of the file shows up as a change of the hash.
+type fileHashModifier struct {
+	File string
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (m fileHashModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Set to the SHA-256 of the contents of the file configured in %s", m.File)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (m fileHashModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Set to the SHA-256 of the contents of the file configured in `%s`", m.File)
+}
+
+// Modify runs the logic of the plan modifier.
+func (m fileHashModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	filePath := req.AttributePath.WithoutLastStep().WithAttributeName(m.File)
+
+	var file types.String
+	diags := req.Config.GetAttribute(ctx, filePath, &file)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if file.Null {
+		resp.AttributePlan = types.String{Null: true}
+		return
+	}
+
+	if file.Unknown {
+		resp.AttributePlan = types.String{Unknown: true}
+		return
+	}
+
+	contents, err := os.ReadFile(file.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(filePath, "Reading file failed.", fmt.Sprintf("Unable to read %s, got error: %s", file.Value, err))
+		return
+	}
+
+	resp.AttributePlan = types.String{Value: sha256Hex(string(contents))}
+}
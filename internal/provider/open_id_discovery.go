@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const openIdConfigurationPath = "/.well-known/openid-configuration"
+
+// openIdDiscoveryDocument is the OpenID configuration an OpenID provider publishes, see
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata.
+type openIdDiscoveryDocument struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JwksUri               string   `json:"jwks_uri"`
+	ScopesSupported       []string `json:"scopes_supported"`
+	ClaimsSupported       []string `json:"claims_supported"`
+}
+
+func getOpenIdDiscoveryDocument(ctx context.Context, client *http.Client, discoveryUrl string) (*openIdDiscoveryDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("Status code %d", resp.StatusCode))
+	}
+
+	var document openIdDiscoveryDocument
+
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return nil, errors.New(fmt.Sprintf("Response could not be parsed, got error: %s", err))
+	}
+
+	return &document, nil
+}
+
+// getDiscoveryUrl returns the URL of the OpenID configuration and the attribute it is configured by.
+func (d openIdProviderTypeData) getDiscoveryUrl() (string, *tftypes.AttributePath) {
+	if !d.DiscoveryUrl.Null {
+		return d.DiscoveryUrl.Value, tftypes.NewAttributePath().WithAttributeName("discovery_url")
+	}
+
+	return strings.TrimSuffix(d.BaseUrl.Value, "/") + openIdConfigurationPath, tftypes.NewAttributePath().WithAttributeName("base_url")
+}
+
+// discoveryChanged returns whether any of the configuration the OpenID configuration is checked against changed.
+func (d openIdProviderTypeData) discoveryChanged(state openIdProviderTypeData) bool {
+	if !d.BaseUrl.Equal(state.BaseUrl) || !d.DiscoveryUrl.Equal(state.DiscoveryUrl) || !d.Scopes.Equal(state.Scopes) || !d.SkipDiscoveryValidation.Equal(state.SkipDiscoveryValidation) {
+		return true
+	}
+
+	claims, stateClaims := d.getClaims(), state.getClaims()
+
+	if len(claims) != len(stateClaims) {
+		return true
+	}
+
+	for i, claim := range claims {
+		if claim.name != stateClaims[i].name || !claim.path.Equal(stateClaims[i].path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateDiscoveryDocument checks the OpenID configuration of the OpenID provider matches its configuration in EVA,
+// as a misconfigured OpenID provider only fails when users log in. The OpenID provider may not be reachable from
+// where Terraform runs, so failing to get the OpenID configuration is only a warning.
+func (d openIdProviderTypeData) validateDiscoveryDocument(ctx context.Context, client *http.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.BaseUrl.Unknown || d.DiscoveryUrl.Unknown {
+		return diags
+	}
+
+	discoveryUrl, discoveryUrlPath := d.getDiscoveryUrl()
+
+	document, err := getOpenIdDiscoveryDocument(ctx, client, discoveryUrl)
+
+	if err != nil {
+		diags.AddAttributeWarning(
+			discoveryUrlPath,
+			"OpenID configuration not found.",
+			fmt.Sprintf("Unable to get the OpenID configuration from %s, got error: %s. Set `skip_discovery_validation` to skip this check.", discoveryUrl, err),
+		)
+		return diags
+	}
+
+	if strings.TrimSuffix(document.Issuer, "/") != strings.TrimSuffix(d.BaseUrl.Value, "/") {
+		diags.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("base_url"),
+			"Issuer does not match.",
+			fmt.Sprintf("The OpenID configuration at %s has issuer %q, expected the base URL %q.", discoveryUrl, document.Issuer, d.BaseUrl.Value),
+		)
+	}
+
+	endpoints := []struct {
+		name  string
+		value string
+	}{
+		{name: "authorization_endpoint", value: document.AuthorizationEndpoint},
+		{name: "token_endpoint", value: document.TokenEndpoint},
+		{name: "jwks_uri", value: document.JwksUri},
+	}
+
+	for _, endpoint := range endpoints {
+		if endpointUrl, err := url.Parse(endpoint.value); err != nil || !endpointUrl.IsAbs() {
+			diags.AddAttributeError(
+				discoveryUrlPath,
+				"Invalid OpenID configuration.",
+				fmt.Sprintf("The OpenID configuration at %s has no valid %s, got %q.", discoveryUrl, endpoint.name, endpoint.value),
+			)
+		}
+	}
+
+	// Both lists are optional, and identity providers often leave out custom scopes and claims, so these only warn.
+
+	if document.ScopesSupported != nil {
+		for i, element := range d.Scopes.Elems {
+			scope, ok := element.(types.String)
+
+			// Scopes which are not known yet are checked once they are known.
+			if !ok || scope.Unknown || containsString(document.ScopesSupported, scope.Value) {
+				continue
+			}
+
+			diags.AddAttributeWarning(
+				tftypes.NewAttributePath().WithAttributeName("scopes").WithElementKeyInt(i),
+				"Scope not supported.",
+				fmt.Sprintf("The OpenID configuration at %s does not list %q in scopes_supported: %s.", discoveryUrl, scope.Value, strings.Join(document.ScopesSupported, ", ")),
+			)
+		}
+	}
+
+	if document.ClaimsSupported != nil {
+		for _, claim := range d.getClaims() {
+			if !containsString(document.ClaimsSupported, claim.name) {
+				diags.AddAttributeWarning(
+					claim.path,
+					"Claim not supported.",
+					fmt.Sprintf("The OpenID configuration at %s does not list %q in claims_supported: %s.", discoveryUrl, claim.name, strings.Join(document.ClaimsSupported, ", ")),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+type openIdProviderClaim struct {
+	name string
+	path *tftypes.AttributePath
+}
+
+// getClaims returns the configured claims with the attribute they are configured by.
+func (d openIdProviderTypeData) getClaims() []openIdProviderClaim {
+	var claims []openIdProviderClaim
+
+	attributes := []struct {
+		name  string
+		value types.String
+	}{
+		{name: "first_name_claim", value: d.FirstNameClaim},
+		{name: "last_name_claim", value: d.LastNameClaim},
+		{name: "email_address_claim", value: d.EmailAddressClaim},
+		{name: "nickname_claim", value: d.NicknameClaim},
+		{name: "role_claim", value: d.RoleClaim},
+	}
+
+	for _, attribute := range attributes {
+		if attribute.value.Value != "" {
+			claims = append(claims, openIdProviderClaim{
+				name: attribute.value.Value,
+				path: tftypes.NewAttributePath().WithAttributeName(attribute.name),
+			})
+		}
+	}
+
+	for i, organizationUnit := range d.DefaultOrganizationUnits {
+		if organizationUnit.Claim.Value != "" {
+			claims = append(claims, openIdProviderClaim{
+				name: organizationUnit.Claim.Value,
+				path: tftypes.NewAttributePath().WithAttributeName("default_organization_units").WithElementKeyInt(i).WithAttributeName("claim"),
+			})
+		}
+	}
+
+	return claims
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
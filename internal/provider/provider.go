@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -14,6 +16,12 @@ import (
 type provider struct {
 	evaClient eva.Client
 
+	// requestTimeout bounds every single request, to EVA and to other services like OpenID providers.
+	requestTimeout time.Duration
+
+	// primaryOpenIdProviders are shared by the OpenID provider resources, to check only one of them is primary.
+	primaryOpenIdProviders *primaryOpenIdProviders
+
 	// configured is set to true at the end of the Configure method.
 	// This can be used in Resource and DataSource implementations to verify
 	// that the provider was previously configured.
@@ -26,10 +34,11 @@ type provider struct {
 }
 
 type providerData struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Token    types.String `tfsdk:"token"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Token          types.String `tfsdk:"token"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	RequestTimeout types.String `tfsdk:"request_timeout"`
 }
 
 func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
@@ -49,6 +58,21 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 
 	p.evaClient = *eva.NewClient(data.Endpoint.Value)
 
+	// Every plan configures the provider, the OpenID providers are claimed to be primary again by that plan.
+	p.primaryOpenIdProviders = newPrimaryOpenIdProviders()
+
+	requestTimeout := defaultRequestTimeout
+
+	// The timeout is unknown when it depends on other resources, the default is used until it is known.
+	if !data.RequestTimeout.Null && !data.RequestTimeout.Unknown {
+		if configured, err := time.ParseDuration(data.RequestTimeout.Value); err == nil {
+			requestTimeout = configured
+		}
+	}
+
+	p.requestTimeout = requestTimeout
+	p.evaClient.SetRequestTimeout(requestTimeout)
+
 	if !data.Token.Null {
 		p.evaClient.SetAuthorizationHeader(data.Token.Value)
 	} else {
@@ -71,20 +95,26 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 
 func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
-		"eva_organization_unit":   organizationUnitType{},
-		"eva_role":                roleType{},
-		"eva_setting":             settingType{},
-		"eva_cookbook":            cookbookType{},
-		"eva_stencil":             stencilType{},
-		"eva_open_id_provider":    openIdProviderType{},
-		"eva_custom_order_status": customOrderStatusType{},
-		"eva_employee":            employeeType{},
-		"eva_order_ledger_type":   orderLedgerTypeSchema{},
+		"eva_organization_unit":        organizationUnitType{},
+		"eva_organization_unit_set":    organizationUnitSetType{},
+		"eva_role":                     roleType{},
+		"eva_setting":                  settingType{},
+		"eva_cookbook":                 cookbookType{},
+		"eva_stencil":                  stencilType{},
+		"eva_open_id_provider":         openIdProviderType{},
+		"eva_primary_open_id_provider": primaryOpenIdProviderType{},
+		"eva_custom_order_status":      customOrderStatusType{},
+		"eva_employee":                 employeeType{},
+		"eva_employees":                employeesType{},
+		"eva_order_ledger_type":        orderLedgerTypeSchema{},
 	}, nil
 }
 
 func (p *provider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
-	return map[string]tfsdk.DataSourceType{}, nil
+	return map[string]tfsdk.DataSourceType{
+		"eva_cookbook_simulation": cookbookSimulationType{},
+		"eva_stencil_preview":     stencilPreviewType{},
+	}, nil
 }
 
 func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
@@ -110,6 +140,14 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Optional:            true,
 				Type:                types.StringType,
 			},
+			"request_timeout": {
+				MarkdownDescription: "Timeout of a single request to EVA, e.g. `30s` or `2m`. Defaults to `1m`. Operations on resources can consist of multiple requests, these are bounded by the `timeouts` of the resource.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					durationValidator{},
+				},
+			},
 		},
 	}, nil
 }
@@ -117,11 +155,18 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 func New(version string) func() tfsdk.Provider {
 	return func() tfsdk.Provider {
 		return &provider{
-			version: version,
+			version:                version,
+			requestTimeout:         defaultRequestTimeout,
+			primaryOpenIdProviders: newPrimaryOpenIdProviders(),
 		}
 	}
 }
 
+// httpClient returns a client for requests to other services than EVA, bounded by the request timeout.
+func (p provider) httpClient() *http.Client {
+	return &http.Client{Timeout: p.requestTimeout}
+}
+
 func convertProviderType(in tfsdk.Provider) (provider, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -1,10 +1,30 @@
 package provider
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+// The acceptance tests run against an in-memory fake of the EVA API, unless an endpoint of a real tenant is given.
+// The flags are only defined in this package, e.g.
+// go test ./internal/provider -eva.endpoint https://api.tenant.eva-online.cloud -eva.token <token>
+// while the environment variables also work for all packages, e.g.
+// EVA_ENDPOINT=https://api.tenant.eva-online.cloud EVA_TOKEN=<token> go test ./...
+var (
+	testAccEndpoint = flag.String("eva.endpoint", os.Getenv("EVA_ENDPOINT"), "Base URL of the EVA API to run the acceptance tests against, defaults to an in-memory fake.")
+	testAccToken    = flag.String("eva.token", os.Getenv("EVA_TOKEN"), "Token used to authenticate to the EVA API.")
+	testAccUsername = flag.String("eva.username", os.Getenv("EVA_USERNAME"), "Username used to log in to the EVA API.")
+	testAccPassword = flag.String("eva.password", os.Getenv("EVA_PASSWORD"), "Password used to log in to the EVA API.")
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -12,13 +32,161 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": func() (tfprotov6.ProviderServer, error) {
-		return tfsdk.NewProtocol6Server(New("test")()), nil
+	"eva": func() (tfprotov6.ProviderServer, error) {
+		return NewServer("test"), nil
 	},
 }
 
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	if *testAccEndpoint == "" {
+		server := evatest.NewServer()
+
+		*testAccEndpoint = server.URL
+		*testAccToken = ""
+		*testAccUsername = evatest.Username
+		*testAccPassword = evatest.Password
+
+		code := m.Run()
+
+		server.Close()
+		os.Exit(code)
+	}
+
+	os.Exit(m.Run())
+}
+
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	if *testAccToken == "" && (*testAccUsername == "" || *testAccPassword == "") {
+		t.Fatal("-eva.token or -eva.username and -eva.password must be set to run the acceptance tests against a real tenant.")
+	}
+}
+
+// testAccProviderConfig returns the configuration of the provider for the EVA API the acceptance tests run against.
+func testAccProviderConfig() string {
+	if *testAccToken != "" {
+		return fmt.Sprintf(`
+provider "eva" {
+	endpoint = %q
+	token    = %q
+}
+`, *testAccEndpoint, *testAccToken)
+	}
+
+	return fmt.Sprintf(`
+provider "eva" {
+	endpoint = %q
+	username = %q
+	password = %q
+}
+`, *testAccEndpoint, *testAccUsername, *testAccPassword)
+}
+
+// testProvider returns a configured provider using the fake EVA API, to test resources without Terraform.
+func testProvider(server *evatest.Server) provider {
+	p := New("test")().(*provider)
+	p.evaClient = *server.NewClient()
+	p.configured = true
+
+	return *p
+}
+
+// testPlanResourceChange plans the change of a resource from the prior to the proposed data through the protocol server,
+// like Terraform does, so plan modifiers of the framework like RequiresReplace are applied as well.
+func testPlanResourceChange(t *testing.T, typeName string, prior interface{}, proposed interface{}) *tfprotov6.PlanResourceChangeResponse {
+	ctx := context.Background()
+
+	resourceTypes, _ := New("test")().GetResources(ctx)
+	schema, _ := resourceTypes[typeName].GetSchema(ctx)
+
+	priorState := tfsdk.State{Schema: schema}
+	proposedState := tfsdk.State{Schema: schema}
+
+	if diags := priorState.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if diags := proposedState.Set(ctx, proposed); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	priorValue, err := tfprotov6.NewDynamicValue(schema.TerraformType(ctx), priorState.Raw)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	proposedValue, err := tfprotov6.NewDynamicValue(schema.TerraformType(ctx), proposedState.Raw)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := NewServer("test").PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &priorValue,
+		ProposedNewState: &proposedValue,
+		Config:           &proposedValue,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return resp
+}
+
+// testImportStateAndRead imports the resource by the ID and reads it, like Terraform does on import.
+func testImportStateAndRead(ctx context.Context, r tfsdk.Resource, schema tfsdk.Schema, id string) (tfsdk.State, diag.Diagnostics) {
+	importResp := tfsdk.ImportResourceStateResponse{State: tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.TerraformType(ctx), nil)}}
+	r.ImportState(ctx, tfsdk.ImportResourceStateRequest{ID: id}, &importResp)
+
+	if importResp.Diagnostics.HasError() {
+		return importResp.State, importResp.Diagnostics
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: importResp.State}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: importResp.State}, &readResp)
+
+	return readResp.State, readResp.Diagnostics
+}
+
+func TestProviderConfigureUnknownRequestTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	p := New("test")().(*provider)
+	schema, _ := p.GetSchema(ctx)
+
+	config := tfsdk.Config{
+		Schema: schema,
+		Raw: tftypes.NewValue(schema.TerraformType(ctx), map[string]tftypes.Value{
+			"endpoint":        tftypes.NewValue(tftypes.String, server.URL),
+			"token":           tftypes.NewValue(tftypes.String, nil),
+			"username":        tftypes.NewValue(tftypes.String, evatest.Username),
+			"password":        tftypes.NewValue(tftypes.String, evatest.Password),
+			"request_timeout": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	}
+
+	// A previous plan of the same provider claimed an OpenID provider to be primary.
+	p.primaryOpenIdProviders.claim("1", "eva_open_id_provider")
+
+	resp := tfsdk.ConfigureProviderResponse{}
+	p.Configure(ctx, tfsdk.ConfigureProviderRequest{Config: config}, &resp)
+
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	if !p.configured {
+		t.Error("expected the provider to be configured with the default request timeout")
+	}
+
+	if _, ok := p.primaryOpenIdProviders.claim("2", "eva_primary_open_id_provider"); !ok {
+		t.Error("expected configuring the provider to start without primary OpenID providers")
+	}
 }
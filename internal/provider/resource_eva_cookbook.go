@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -34,15 +37,82 @@ func (t cookbookType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnos
 				Type:                types.StringType,
 			},
 			"recipe": {
-				MarkdownDescription: "Value of the cookbook",
-				Required:            true,
+				MarkdownDescription: "Recipe of the cookbook in the cookbook language of EVA. Conflicts with `rules`, when `rules` is configured this is the recipe rendered from the rules.",
+				Optional:            true,
+				Computed:            true,
 				Type:                types.StringType,
 			},
+			"rules": {
+				MarkdownDescription: "Rules of the recipe, as an alternative to writing the `recipe` itself. Conflicts with `recipe`.",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"name": {
+							MarkdownDescription: "Name of the rule.",
+							Required:            true,
+							Type:                types.StringType,
+						},
+						"event": {
+							MarkdownDescription: "Financial event the rule books, e.g. `OrderInvoiced`.",
+							Required:            true,
+							Type:                types.StringType,
+						},
+						"condition": {
+							MarkdownDescription: "Condition the event has to match for the rule to book it.",
+							Optional:            true,
+							Type:                types.StringType,
+						},
+						"bookings": {
+							MarkdownDescription: "Bookings of the rule.",
+							Required:            true,
+							Attributes: tfsdk.ListNestedAttributes(
+								map[string]tfsdk.Attribute{
+									"debit": {
+										MarkdownDescription: "Ledger account that is debited.",
+										Required:            true,
+										Type:                types.StringType,
+									},
+									"credit": {
+										MarkdownDescription: "Ledger account that is credited.",
+										Required:            true,
+										Type:                types.StringType,
+									},
+									"amount": {
+										MarkdownDescription: "Expression of the amount that is booked, e.g. `Invoice.TotalAmount`.",
+										Required:            true,
+										Type:                types.StringType,
+									},
+									"description": {
+										MarkdownDescription: "Description of the booking.",
+										Optional:            true,
+										Type:                types.StringType,
+									},
+								},
+								tfsdk.ListNestedAttributesOptions{},
+							),
+						},
+					},
+					tfsdk.ListNestedAttributesOptions{},
+				),
+			},
 			"is_active": {
-				MarkdownDescription: "boolean of whether the cookbook is active.",
+				MarkdownDescription: "boolean of whether the cookbook is active. When the recipe of an active cookbook changes, the new recipe is created as a new inactive cookbook, validated, and then activated in place of the current cookbook.",
 				Optional:            true,
 				Type:                types.BoolType,
 			},
+			"superseded_ids": {
+				MarkdownDescription: "IDs of the previous versions of the cookbook, that were replaced by a new version when the recipe of the active cookbook changed. The oldest version is first.",
+				Computed:            true,
+				Type: types.ListType{
+					ElemType: types.Int64Type,
+				},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -56,26 +126,250 @@ func (t cookbookType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk
 }
 
 type cookbookTypeData struct {
-	ID       types.Int64  `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Recipe   types.String `tfsdk:"recipe"`
-	IsActive types.Bool   `tfsdk:"is_active"`
+	ID            types.Int64        `tfsdk:"id"`
+	Name          types.String       `tfsdk:"name"`
+	Recipe        types.String       `tfsdk:"recipe"`
+	Rules         []cookbookRuleData `tfsdk:"rules"`
+	IsActive      types.Bool         `tfsdk:"is_active"`
+	SupersededIDs types.List         `tfsdk:"superseded_ids"`
+	Timeouts      timeouts           `tfsdk:"timeouts"`
+}
+
+type cookbookRuleData struct {
+	Name      types.String          `tfsdk:"name"`
+	Event     types.String          `tfsdk:"event"`
+	Condition types.String          `tfsdk:"condition"`
+	Bookings  []cookbookBookingData `tfsdk:"bookings"`
+}
+
+type cookbookBookingData struct {
+	Debit       types.String `tfsdk:"debit"`
+	Credit      types.String `tfsdk:"credit"`
+	Amount      types.String `tfsdk:"amount"`
+	Description types.String `tfsdk:"description"`
+}
+
+// hasUnknownRules returns whether any of the rules is not known yet, so the recipe can't be rendered.
+func (d cookbookTypeData) hasUnknownRules() bool {
+	for _, rule := range d.Rules {
+		if rule.Name.Unknown || rule.Event.Unknown || rule.Condition.Unknown {
+			return true
+		}
+
+		for _, booking := range rule.Bookings {
+			if booking.Debit.Unknown || booking.Credit.Unknown || booking.Amount.Unknown || booking.Description.Unknown {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// renderRecipe renders the rules to a recipe. The same rules always render to the same recipe, so the recipe
+// only changes when the rules change. Each rule is rendered as:
+//
+//	rule "<name>"
+//	  on <event>
+//	  when <condition>
+//	  book <amount> from <credit> to <debit> as "<description>"
+//	end
+//
+// testdata/cookbook contains the recipe rendered for rules, and the same recipe as it is returned by GetAccountingRecipe.
+func (d cookbookTypeData) renderRecipe() string {
+	var recipe strings.Builder
+
+	for i, rule := range d.Rules {
+		if i > 0 {
+			recipe.WriteString("\n")
+		}
+
+		fmt.Fprintf(&recipe, "rule %q\n", rule.Name.Value)
+		fmt.Fprintf(&recipe, "  on %s\n", rule.Event.Value)
+
+		if !rule.Condition.Null {
+			fmt.Fprintf(&recipe, "  when %s\n", rule.Condition.Value)
+		}
+
+		for _, booking := range rule.Bookings {
+			fmt.Fprintf(&recipe, "  book %s from %s to %s", booking.Amount.Value, booking.Credit.Value, booking.Debit.Value)
+
+			if !booking.Description.Null {
+				fmt.Fprintf(&recipe, " as %q", booking.Description.Value)
+			}
+
+			recipe.WriteString("\n")
+		}
+
+		recipe.WriteString("end\n")
+	}
+
+	return recipe.String()
+}
+
+// normalizeRecipe removes the whitespace that has no meaning in a recipe: indentation, blank lines,
+// trailing whitespace and repeated spaces. EVA can format a recipe differently than it was sent.
+// Whitespace in string literals is part of the recipe, so it is kept as it is.
+func normalizeRecipe(recipe string) string {
+	var normalized strings.Builder
+
+	inString, escaped := false, false
+	space, newline := false, false
+
+	for _, r := range recipe {
+		if inString {
+			normalized.WriteRune(r)
+
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+
+			continue
+		}
+
+		switch r {
+		case '\n':
+			newline = true
+			continue
+		case ' ', '\t', '\r':
+			space = true
+			continue
+		}
+
+		if normalized.Len() > 0 {
+			if newline {
+				normalized.WriteRune('\n')
+			} else if space {
+				normalized.WriteRune(' ')
+			}
+		}
+
+		space, newline = false, false
+		inString = r == '"'
+
+		normalized.WriteRune(r)
+	}
+
+	return normalized.String()
 }
 
 type cookbook struct {
 	provider provider
 }
 
+var (
+	cookbookIDPath            = tftypes.NewAttributePath().WithAttributeName("id")
+	cookbookRecipePath        = tftypes.NewAttributePath().WithAttributeName("recipe")
+	cookbookSupersededIDsPath = tftypes.NewAttributePath().WithAttributeName("superseded_ids")
+)
+
+// addRecipeErrors adds an error for each error in the recipe. It returns false when the error is not about the recipe.
+func addRecipeErrors(diags *diag.Diagnostics, err error) bool {
+	var recipeErrors eva.RecipeErrors
+
+	if !errors.As(err, &recipeErrors) {
+		return false
+	}
+
+	// EVA can report that a recipe has errors without returning them, the recipe is still invalid.
+	if len(recipeErrors) == 0 {
+		diags.AddAttributeError(cookbookRecipePath, "Invalid recipe.", recipeErrors.Error())
+
+		return true
+	}
+
+	for _, recipeError := range recipeErrors {
+		diags.AddAttributeError(
+			cookbookRecipePath,
+			"Invalid recipe.",
+			fmt.Sprintf("Line %d, column %d: %s", recipeError.Line, recipeError.Column, recipeError.Message),
+		)
+	}
+
+	return true
+}
+
+func (r cookbook) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	resp.Diagnostics.Append(validateConflictingAttributes(req.Config, true, "recipe", "rules")...)
+}
+
+// ModifyPlan renders the recipe of the rules and validates the recipe with EVA, so recipes with errors are reported before they are applied.
+func (r cookbook) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to validate when the cookbook is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan cookbookTypeData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Rules != nil && !plan.hasUnknownRules() {
+		plan.Recipe = types.String{Value: plan.renderRecipe()}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, cookbookRecipePath, plan.Recipe)...)
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state cookbookTypeData
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// A new recipe for an active cookbook is created as a new version, which replaces the current one.
+		// A recipe or activation which is not known yet may change, so the new version is planned then as well.
+		if state.IsActive.Value && (plan.IsActive.Value || plan.IsActive.Unknown) && (plan.Recipe.Unknown || !state.Recipe.Equal(plan.Recipe)) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, cookbookIDPath, types.Int64{Unknown: true})...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, cookbookSupersededIDsPath, types.List{ElemType: types.Int64Type, Unknown: true})...)
+		}
+
+		// The recipe was already validated when it was applied.
+		if state.Recipe.Equal(plan.Recipe) {
+			return
+		}
+	}
+
+	if plan.Recipe.Unknown {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.read(ctx)
+	defer cancel()
+
+	_, err := r.provider.evaClient.ValidateAccountingRecipe(ctx, eva.ValidateAccountingRecipeRequest{
+		Recipe: plan.Recipe.Value,
+	})
+
+	// The recipe is validated again when it is applied, so it is not a problem when the validation itself fails.
+	if err != nil && !addRecipeErrors(&resp.Diagnostics, err) {
+		tflog.Info(ctx, "Unable to validate the recipe.", "error", err)
+	}
+}
+
 func (r cookbook) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data cookbookTypeData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
 	client_resp, err := r.provider.evaClient.CreateAccountingRecipe(ctx, eva.CreateAccountingRecipeRequest{
 		Name:     data.Name.Value,
 		Recipe:   data.Recipe.Value,
@@ -83,11 +377,14 @@ func (r cookbook) Create(ctx context.Context, req tfsdk.CreateResourceRequest, r
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Creating cookbook unit failed.", fmt.Sprintf("Unable to create cookbook, got error: %s", err))
+		if !addRecipeErrors(&resp.Diagnostics, err) {
+			resp.Diagnostics.AddError("Creating cookbook unit failed.", fmt.Sprintf("Unable to create cookbook, got error: %s", err))
+		}
 		return
 	}
 
 	data.ID = types.Int64{Value: client_resp.ID}
+	data.SupersededIDs = types.List{ElemType: types.Int64Type, Elems: []attr.Value{}}
 
 	tflog.Trace(ctx, "Created an cookbook.")
 
@@ -105,10 +402,19 @@ func (r cookbook) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	client_resp, err := r.provider.evaClient.GetAccountingRecipe(ctx, eva.GetAccountingRecipeRequest{
 		ID: data.ID.Value,
 	})
 
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The cookbook no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Creating cookbook unit failed.", fmt.Sprintf("Unable to create example, got error: %s", err))
 		return
@@ -116,22 +422,111 @@ func (r cookbook) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp
 
 	data.IsActive = types.Bool{Value: client_resp.Recipe.IsActive}
 	data.Name = types.String{Value: client_resp.Recipe.Name}
-	data.Recipe = types.String{Value: client_resp.Recipe.Recipe}
+
+	// Differences in whitespace only are not a change of the recipe.
+	if data.Recipe.Null || normalizeRecipe(data.Recipe.Value) != normalizeRecipe(client_resp.Recipe.Recipe) {
+		data.Recipe = types.String{Value: client_resp.Recipe.Recipe}
+	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
+// createVersion creates a new version of an active cookbook. The new version is created inactive and validated,
+// and then activated while the current version is deactivated, so only one of them is active at any time.
+func (r cookbook) createVersion(ctx context.Context, data *cookbookTypeData, state cookbookTypeData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := r.provider.evaClient.ValidateAccountingRecipe(ctx, eva.ValidateAccountingRecipeRequest{
+		Recipe: data.Recipe.Value,
+	})
+
+	if err != nil {
+		if !addRecipeErrors(&diags, err) {
+			diags.AddError("Validating cookbook failed.", fmt.Sprintf("Unable to validate cookbook, got error: %s", err))
+		}
+		return diags
+	}
+
+	client_resp, err := r.provider.evaClient.CreateAccountingRecipe(ctx, eva.CreateAccountingRecipeRequest{
+		Name:     data.Name.Value,
+		Recipe:   data.Recipe.Value,
+		IsActive: false,
+	})
+
+	if err != nil {
+		if !addRecipeErrors(&diags, err) {
+			diags.AddError("Creating cookbook version failed.", fmt.Sprintf("Unable to create a new version of the cookbook, got error: %s", err))
+		}
+		return diags
+	}
+
+	_, err = r.provider.evaClient.ActivateAccountingRecipe(ctx, eva.ActivateAccountingRecipeRequest{
+		ID:            client_resp.ID,
+		DeactivateIDs: []int64{state.ID.Value},
+	})
+
+	if err != nil {
+		diags.AddError("Activating cookbook version failed.", fmt.Sprintf("Unable to activate version %d of the cookbook, the current version %d is still active, got error: %s", client_resp.ID, state.ID.Value, err))
+
+		// The new version is not used, so it is removed to not leave it behind.
+		if _, err := r.provider.evaClient.DeleteAccountingRecipe(ctx, eva.DeleteAccountingRecipeRequest{ID: client_resp.ID}); err != nil {
+			tflog.Warn(ctx, "Unable to delete the new version of the cookbook.", "id", client_resp.ID, "error", err)
+		}
+
+		return diags
+	}
+
+	tflog.Info(ctx, "Activated a new version of the cookbook.", "id", client_resp.ID, "superseded_id", state.ID.Value)
+
+	var supersededIDs []attr.Value
+
+	if !state.SupersededIDs.Null && !state.SupersededIDs.Unknown {
+		supersededIDs = append(supersededIDs, state.SupersededIDs.Elems...)
+	}
+
+	data.ID = types.Int64{Value: client_resp.ID}
+	data.SupersededIDs = types.List{ElemType: types.Int64Type, Elems: append(supersededIDs, types.Int64{Value: state.ID.Value})}
+
+	return diags
+}
+
 func (r cookbook) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
 	var data cookbookTypeData
+	var state cookbookTypeData
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
+	// The ID is only unknown when a new version of the cookbook may be needed. Whether it is needed is only known
+	// now, when the recipe and activation were unknown during the plan.
+	if data.ID.Unknown {
+		if state.IsActive.Value && data.IsActive.Value && !state.Recipe.Equal(data.Recipe) {
+			resp.Diagnostics.Append(r.createVersion(ctx, &data, state)...)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			diags = resp.State.Set(ctx, &data)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		data.ID = state.ID
+		data.SupersededIDs = state.SupersededIDs
+	}
+
 	_, err := r.provider.evaClient.UpdateAccountingRecipe(ctx, eva.UpdateAccountingRecipeRequest{
 		ID:       data.ID.Value,
 		Name:     data.Name.Value,
@@ -140,7 +535,9 @@ func (r cookbook) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, r
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Updating cookbook unit failed.", fmt.Sprintf("Unable to update cookbook, got error: %s", err))
+		if !addRecipeErrors(&resp.Diagnostics, err) {
+			resp.Diagnostics.AddError("Updating cookbook unit failed.", fmt.Sprintf("Unable to update cookbook, got error: %s", err))
+		}
 		return
 	}
 
@@ -158,6 +555,9 @@ func (r cookbook) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, r
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.DeleteAccountingRecipe(ctx, eva.DeleteAccountingRecipeRequest{
 		ID: data.ID.Value,
 	})
@@ -0,0 +1,564 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestCookbookRenderRecipe(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rules    []cookbookRuleData
+		expected string
+	}{
+		{
+			name:     "no rules",
+			rules:    []cookbookRuleData{},
+			expected: "",
+		},
+		{
+			name: "rules",
+			rules: []cookbookRuleData{
+				{
+					Name:      types.String{Value: "invoices"},
+					Event:     types.String{Value: "OrderInvoiced"},
+					Condition: types.String{Null: true},
+					Bookings: []cookbookBookingData{
+						{
+							Debit:       types.String{Value: "1300"},
+							Credit:      types.String{Value: "8000"},
+							Amount:      types.String{Value: "Invoice.TotalAmount"},
+							Description: types.String{Value: "Invoice \"total\""},
+						},
+					},
+				},
+				{
+					Name:      types.String{Value: "refunds"},
+					Event:     types.String{Value: "OrderRefunded"},
+					Condition: types.String{Value: "Refund.Amount > 0"},
+					Bookings: []cookbookBookingData{
+						{
+							Debit:       types.String{Value: "8000"},
+							Credit:      types.String{Value: "1300"},
+							Amount:      types.String{Value: "Refund.Amount"},
+							Description: types.String{Null: true},
+						},
+					},
+				},
+			},
+			expected: `rule "invoices"
+  on OrderInvoiced
+  book Invoice.TotalAmount from 8000 to 1300 as "Invoice \"total\""
+end
+
+rule "refunds"
+  on OrderRefunded
+  when Refund.Amount > 0
+  book Refund.Amount from 1300 to 8000
+end
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := cookbookTypeData{Rules: testCase.rules}.renderRecipe()
+
+			if actual != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+// TestCookbookRecipeFixtures renders rules to the recipe in testdata/cookbook/rules.recipe, and compares it to the
+// recipe in the GetAccountingRecipe response in testdata/cookbook/get_accounting_recipe.json, which EVA formatted differently.
+func TestCookbookRecipeFixtures(t *testing.T) {
+	ctx := context.Background()
+
+	data := cookbookTypeData{
+		Rules: []cookbookRuleData{
+			{
+				Name:      types.String{Value: "invoices"},
+				Event:     types.String{Value: "OrderInvoiced"},
+				Condition: types.String{Null: true},
+				Bookings: []cookbookBookingData{
+					{
+						Debit:       types.String{Value: "1300"},
+						Credit:      types.String{Value: "8000"},
+						Amount:      types.String{Value: "Invoice.TotalAmount"},
+						Description: types.String{Value: "Invoiced orders"},
+					},
+					{
+						Debit:       types.String{Value: "1300"},
+						Credit:      types.String{Value: "1500"},
+						Amount:      types.String{Value: "Invoice.TaxAmount"},
+						Description: types.String{Value: "Tax of  invoiced orders"},
+					},
+				},
+			},
+			{
+				Name:      types.String{Value: "refunds"},
+				Event:     types.String{Value: "OrderRefunded"},
+				Condition: types.String{Value: "Refund.Amount > 0"},
+				Bookings: []cookbookBookingData{
+					{
+						Debit:       types.String{Value: "8000"},
+						Credit:      types.String{Value: "1300"},
+						Amount:      types.String{Value: "Refund.Amount"},
+						Description: types.String{Value: "Refund \"total\""},
+					},
+				},
+			},
+		},
+	}
+
+	rendered, err := os.ReadFile("testdata/cookbook/rules.recipe")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if actual := data.renderRecipe(); actual != string(rendered) {
+		t.Errorf("expected %q, got %q", rendered, actual)
+	}
+
+	body, err := os.ReadFile("testdata/cookbook/get_accounting_recipe.json")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var returned eva.GetAccountingRecipeResponse
+
+	if err := json.Unmarshal(body, &returned); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if normalizeRecipe(returned.Recipe.Recipe) != normalizeRecipe(string(rendered)) {
+		t.Errorf("expected the returned recipe %q to equal the rendered recipe %q", returned.Recipe.Recipe, rendered)
+	}
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	for _, recipe := range []string{string(rendered), returned.Recipe.Recipe} {
+		if _, err := server.NewClient().ValidateAccountingRecipe(ctx, eva.ValidateAccountingRecipeRequest{Recipe: recipe}); err != nil {
+			t.Errorf("expected recipe %q to be valid, got error: %s", recipe, err)
+		}
+	}
+}
+
+func TestCookbookNormalizeRecipe(t *testing.T) {
+	testCases := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{
+			name:  "indentation and blank lines",
+			a:     "rule \"a\"\n  on OrderInvoiced\n\nend\n",
+			b:     "rule \"a\"\r\non   OrderInvoiced\r\nend",
+			equal: true,
+		},
+		{
+			name:  "whitespace in strings",
+			a:     "rule \"a\"\n  book Invoice.TotalAmount from 8000 to 1300 as \"Invoiced  orders\"\nend\n",
+			b:     "rule \"a\"\n  book Invoice.TotalAmount from 8000 to 1300 as \"Invoiced orders\"\nend\n",
+			equal: false,
+		},
+		{
+			name:  "escaped quotes in strings",
+			a:     "rule \"a \\\"b  c\\\"\"\n  on   OrderInvoiced\nend\n",
+			b:     "rule \"a \\\"b  c\\\"\"\r\non OrderInvoiced\r\nend",
+			equal: true,
+		},
+		{
+			name:  "whitespace after escaped quotes in strings",
+			a:     "rule \"a \\\"b  c\\\"\"\n  on OrderInvoiced\nend\n",
+			b:     "rule \"a \\\"b c\\\"\"\n  on OrderInvoiced\nend\n",
+			equal: false,
+		},
+		{
+			name:  "whitespace after strings",
+			a:     "rule \"a\"   \n  on OrderInvoiced\nend\n",
+			b:     "rule \"a\" on OrderInvoiced end",
+			equal: false,
+		},
+		{
+			name:  "different recipes",
+			a:     "rule \"a\"\n  on OrderInvoiced\nend\n",
+			b:     "rule \"a\"\n  on OrderRefunded\nend\n",
+			equal: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if equal := normalizeRecipe(testCase.a) == normalizeRecipe(testCase.b); equal != testCase.equal {
+				t.Errorf("expected recipes to be equal: %t, got %t", testCase.equal, equal)
+			}
+		})
+	}
+}
+
+func TestCookbookAddRecipeErrors(t *testing.T) {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedDetails []string
+	}{
+		{
+			name: "recipe errors",
+			err: eva.RecipeErrors{
+				{Message: "Unknown event 'OrderInvoice'.", Line: 2, Column: 6},
+				{Message: "Expected 'end'.", Line: 4, Column: 1},
+			},
+			expectedDetails: []string{
+				"Line 2, column 6: Unknown event 'OrderInvoice'.",
+				"Line 4, column 1: Expected 'end'.",
+			},
+		},
+		{
+			name:            "recipe errors which are not returned",
+			err:             eva.RecipeErrors{},
+			expectedDetails: []string{"Recipe has errors, but EVA didn't return which."},
+		},
+		{
+			name: "other error",
+			err:  errors.New("Request failed."),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			if added := addRecipeErrors(&diags, testCase.err); added != (testCase.expectedDetails != nil) {
+				t.Fatalf("expected the errors to be added: %t", !added)
+			}
+
+			if len(diags) != len(testCase.expectedDetails) {
+				t.Fatalf("expected %d diagnostics, got %v", len(testCase.expectedDetails), diags)
+			}
+
+			for i, d := range diags {
+				withPath, ok := d.(interface{ Path() *tftypes.AttributePath })
+
+				if d.Severity() != diag.SeverityError || !ok || !withPath.Path().Equal(cookbookRecipePath) || d.Detail() != testCase.expectedDetails[i] {
+					t.Errorf("expected an error on the recipe %q, got %s: %s", testCase.expectedDetails[i], d.Summary(), d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestCookbookModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := cookbook{provider: testProvider(server)}
+	schema, _ := cookbookType{}.GetSchema(ctx)
+
+	testCases := []struct {
+		name               string
+		stateIsActive      bool
+		isActive           types.Bool
+		recipe             types.String
+		expectedNewVersion bool
+	}{
+		{
+			name:               "recipe of an active cookbook changes",
+			stateIsActive:      true,
+			isActive:           types.Bool{Value: true},
+			recipe:             types.String{Value: testCookbookRecipe("OrderRefunded")},
+			expectedNewVersion: true,
+		},
+		{
+			name:               "recipe of an active cookbook is unknown",
+			stateIsActive:      true,
+			isActive:           types.Bool{Value: true},
+			recipe:             types.String{Unknown: true},
+			expectedNewVersion: true,
+		},
+		{
+			name:               "activation of an active cookbook is unknown",
+			stateIsActive:      true,
+			isActive:           types.Bool{Unknown: true},
+			recipe:             types.String{Value: testCookbookRecipe("OrderRefunded")},
+			expectedNewVersion: true,
+		},
+		{
+			name:          "recipe of an active cookbook is unchanged",
+			stateIsActive: true,
+			isActive:      types.Bool{Value: true},
+			recipe:        types.String{Value: testCookbookRecipe("OrderInvoiced")},
+		},
+		{
+			name:          "recipe of an inactive cookbook changes",
+			stateIsActive: false,
+			isActive:      types.Bool{Value: false},
+			recipe:        types.String{Value: testCookbookRecipe("OrderRefunded")},
+		},
+		{
+			name:          "active cookbook is deactivated",
+			stateIsActive: true,
+			isActive:      types.Bool{Value: false},
+			recipe:        types.String{Value: testCookbookRecipe("OrderRefunded")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			stateData := testCookbookData(types.Int64{Value: 1}, testCookbookRecipe("OrderInvoiced"), testCase.stateIsActive)
+			planData := stateData
+			planData.IsActive = testCase.isActive
+			planData.Recipe = testCase.recipe
+
+			state := tfsdk.State{Schema: schema}
+			state.Set(ctx, &stateData)
+
+			plan := tfsdk.Plan{Schema: schema}
+			plan.Set(ctx, &planData)
+
+			resp := tfsdk.ModifyResourcePlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, tfsdk.ModifyResourcePlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, Plan: plan, State: state}, &resp)
+
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+			}
+
+			var planned cookbookTypeData
+
+			resp.Plan.Get(ctx, &planned)
+
+			if planned.ID.Unknown != testCase.expectedNewVersion || planned.SupersededIDs.Unknown != testCase.expectedNewVersion {
+				t.Errorf("expected a new version to be planned: %t, got ID %v and superseded IDs %v", testCase.expectedNewVersion, planned.ID, planned.SupersededIDs)
+			}
+		})
+	}
+}
+
+func TestCookbookUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name               string
+		recipe             string
+		isActive           bool
+		knownID            bool
+		expectedNewVersion bool
+	}{
+		{
+			name:               "recipe changed",
+			recipe:             testCookbookRecipe("OrderRefunded"),
+			isActive:           true,
+			expectedNewVersion: true,
+		},
+		{
+			name:     "recipe which was unknown is unchanged",
+			recipe:   testCookbookRecipe("OrderInvoiced"),
+			isActive: true,
+		},
+		{
+			name:     "activation which was unknown is deactivated",
+			recipe:   testCookbookRecipe("OrderRefunded"),
+			isActive: false,
+		},
+		{
+			name:     "deactivated",
+			recipe:   testCookbookRecipe("OrderInvoiced"),
+			isActive: false,
+			knownID:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := evatest.NewServer()
+			defer server.Close()
+
+			client := server.NewClient()
+			r := cookbook{provider: testProvider(server)}
+			schema, _ := cookbookType{}.GetSchema(ctx)
+
+			created, err := client.CreateAccountingRecipe(ctx, eva.CreateAccountingRecipeRequest{Name: "my recipe", Recipe: testCookbookRecipe("OrderInvoiced"), IsActive: true})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			stateData := testCookbookData(types.Int64{Value: created.ID}, testCookbookRecipe("OrderInvoiced"), true)
+			planData := testCookbookData(types.Int64{Unknown: true}, testCase.recipe, testCase.isActive)
+			planData.SupersededIDs = types.List{ElemType: types.Int64Type, Unknown: true}
+
+			if testCase.knownID {
+				planData.ID = stateData.ID
+				planData.SupersededIDs = stateData.SupersededIDs
+			}
+
+			state := tfsdk.State{Schema: schema}
+			state.Set(ctx, &stateData)
+
+			plan := tfsdk.Plan{Schema: schema}
+			plan.Set(ctx, &planData)
+
+			resp := tfsdk.UpdateResourceResponse{State: state}
+			r.Update(ctx, tfsdk.UpdateResourceRequest{Plan: plan, State: state}, &resp)
+
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+			}
+
+			var updated cookbookTypeData
+
+			resp.State.Get(ctx, &updated)
+
+			if newVersion := updated.ID.Value != created.ID; newVersion != testCase.expectedNewVersion {
+				t.Fatalf("expected a new version to be created: %t, got ID %v", testCase.expectedNewVersion, updated.ID)
+			}
+
+			recipe, err := client.GetAccountingRecipe(ctx, eva.GetAccountingRecipeRequest{ID: updated.ID.Value})
+
+			if err != nil || recipe.Recipe.Recipe != testCase.recipe || recipe.Recipe.IsActive != testCase.isActive {
+				t.Errorf("expected cookbook %d to have the planned recipe and activation, got %+v, %v", updated.ID.Value, recipe, err)
+			}
+
+			expected := testInt64List()
+
+			if testCase.expectedNewVersion {
+				expected = testInt64List(created.ID)
+			}
+
+			if !updated.SupersededIDs.Equal(expected) {
+				t.Errorf("expected superseded IDs %v, got %v", expected, updated.SupersededIDs)
+			}
+		})
+	}
+}
+
+func TestCookbookCreateVersion(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	r := cookbook{provider: testProvider(server)}
+
+	first, err := client.CreateAccountingRecipe(ctx, eva.CreateAccountingRecipeRequest{Name: "my recipe", Recipe: testCookbookRecipe("OrderInvoiced"), IsActive: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	state := testCookbookData(types.Int64{Value: first.ID}, testCookbookRecipe("OrderInvoiced"), true)
+	state.SupersededIDs = testInt64List(100)
+
+	data := testCookbookData(types.Int64{Unknown: true}, testCookbookRecipe("OrderRefunded"), true)
+
+	for _, d := range r.createVersion(ctx, &data, state) {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	if expected := testInt64List(100, first.ID); !data.SupersededIDs.Equal(expected) {
+		t.Errorf("expected superseded IDs %v, got %v", expected, data.SupersededIDs)
+	}
+
+	previous, _ := client.GetAccountingRecipe(ctx, eva.GetAccountingRecipeRequest{ID: first.ID})
+	current, _ := client.GetAccountingRecipe(ctx, eva.GetAccountingRecipeRequest{ID: data.ID.Value})
+
+	if previous.Recipe.IsActive || !current.Recipe.IsActive || current.Recipe.Recipe != data.Recipe.Value {
+		t.Errorf("expected version %d to replace version %d, got %+v and %+v", data.ID.Value, first.ID, current, previous)
+	}
+
+	// An invalid recipe is reported and no version is created.
+	invalid := testCookbookData(types.Int64{Unknown: true}, "rule \"invalid\"\n", true)
+	diags := r.createVersion(ctx, &invalid, data)
+
+	if !diags.HasError() || !invalid.ID.Unknown {
+		t.Errorf("expected the invalid recipe to be reported, got %v and ID %v", diags, invalid.ID)
+	}
+}
+
+func TestCookbookCreateVersionActivationFailed(t *testing.T) {
+	ctx := context.Background()
+
+	var deletedIDs []int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/cookbook/ValidateAccountingRecipe":
+			w.Write([]byte(`{"HasErrors": false}`))
+		case "/api/cookbook/CreateAccountingRecipe":
+			w.Write([]byte(`{"ID": 2}`))
+		case "/api/cookbook/DeleteAccountingRecipe":
+			var req eva.DeleteAccountingRecipeRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			deletedIDs = append(deletedIDs, req.ID)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`Internal server error`))
+		}
+	}))
+	defer server.Close()
+
+	r := cookbook{provider: provider{evaClient: *eva.NewClient(server.URL), configured: true}}
+
+	state := testCookbookData(types.Int64{Value: 1}, testCookbookRecipe("OrderInvoiced"), true)
+	data := testCookbookData(types.Int64{Unknown: true}, testCookbookRecipe("OrderRefunded"), true)
+
+	diags := r.createVersion(ctx, &data, state)
+
+	if !diags.HasError() || !data.ID.Unknown {
+		t.Errorf("expected activating the new version to fail, got %v and ID %v", diags, data.ID)
+	}
+
+	if len(deletedIDs) != 1 || deletedIDs[0] != 2 {
+		t.Errorf("expected the new version to be deleted, got %v", deletedIDs)
+	}
+}
+
+func testCookbookRecipe(event string) string {
+	return fmt.Sprintf("rule \"a\"\n  on %s\n  book Invoice.TotalAmount from 8000 to 1300\nend\n", event)
+}
+
+func testCookbookData(id types.Int64, recipe string, isActive bool) cookbookTypeData {
+	return cookbookTypeData{
+		ID:            id,
+		Name:          types.String{Value: "my recipe"},
+		Recipe:        types.String{Value: recipe},
+		IsActive:      types.Bool{Value: isActive},
+		SupersededIDs: testInt64List(),
+		Timeouts:      timeouts{},
+	}
+}
+
+func testInt64List(values ...int64) types.List {
+	list := types.List{ElemType: types.Int64Type, Elems: []attr.Value{}}
+
+	for _, value := range values {
+		list.Elems = append(list.Elems, types.Int64{Value: value})
+	}
+
+	return list
+}
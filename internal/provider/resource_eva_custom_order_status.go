@@ -2,12 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/mad-it/terraform-provider-eva/internal/eva"
@@ -38,6 +38,14 @@ func (t customOrderStatusType) GetSchema(ctx context.Context) (tfsdk.Schema, dia
 				Optional:            true,
 				Type:                types.StringType,
 			},
+			"adopt_existing": {
+				MarkdownDescription: "Adopt an existing custom order status with the same name on create, instead of failing to create it. The adopted custom order status is updated to the configured values.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -51,9 +59,11 @@ func (t customOrderStatusType) NewResource(ctx context.Context, in tfsdk.Provide
 }
 
 type customOrderStatusTypeData struct {
-	ID          types.Int64  `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
+	ID            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+	Timeouts      timeouts     `tfsdk:"timeouts"`
 }
 
 type customOrderStatus struct {
@@ -70,6 +80,39 @@ func (r customOrderStatus) Create(ctx context.Context, req tfsdk.CreateResourceR
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	if data.AdoptExisting.Value {
+		existing, err := r.findByName(ctx, data.Name.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Adopting custom order status failed.", fmt.Sprintf("Unable to find custom order status, got error: %s", err))
+			return
+		}
+
+		if existing != nil {
+			_, err := r.provider.evaClient.UpdateCustomOrderStatus(ctx, eva.UpdateCustomOrderStatusRequest{
+				ID:          existing.ID,
+				Name:        data.Name.Value,
+				Description: data.Description.Value,
+			})
+
+			if err != nil {
+				resp.Diagnostics.AddError("Adopting custom order status failed.", fmt.Sprintf("Unable to update custom order status, got error: %s", err))
+				return
+			}
+
+			data.ID = types.Int64{Value: existing.ID}
+
+			tflog.Info(ctx, "Adopted an existing custom order status.", "id", existing.ID)
+
+			diags = resp.State.Set(ctx, &data)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
 	clientResponse, err := r.provider.evaClient.CreateCustomOrderStatus(ctx, eva.CreateCustomOrderStatusRequest{
 		Name:        data.Name.Value,
 		Description: data.Description.Value,
@@ -98,6 +141,9 @@ func (r customOrderStatus) Read(ctx context.Context, req tfsdk.ReadResourceReque
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	clientResponse, err := r.provider.evaClient.ListCustomOrderStatus(ctx)
 
 	if err != nil {
@@ -117,7 +163,8 @@ func (r customOrderStatus) Read(ctx context.Context, req tfsdk.ReadResourceReque
 	}
 
 	if !customOrderStatusFound {
-		resp.Diagnostics.AddError("Could not find custom order status data.", fmt.Sprintf("Unable to get custom order status"))
+		tflog.Info(ctx, "The custom order status no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -135,6 +182,9 @@ func (r customOrderStatus) Update(ctx context.Context, req tfsdk.UpdateResourceR
 		return
 	}
 
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.UpdateCustomOrderStatus(ctx, eva.UpdateCustomOrderStatusRequest{
 		ID:          data.ID.Value,
 		Name:        data.Name.Value,
@@ -160,6 +210,9 @@ func (r customOrderStatus) Delete(ctx context.Context, req tfsdk.DeleteResourceR
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.DeleteCustomOrderStatus(ctx, eva.DeleteCustomOrderStatusRequest{
 		ID: data.ID.Value,
 	})
@@ -172,6 +225,39 @@ func (r customOrderStatus) Delete(ctx context.Context, req tfsdk.DeleteResourceR
 	resp.State.RemoveResource(ctx)
 }
 
+// findByName returns the custom order status with the name, or nil when there is none.
+func (r customOrderStatus) findByName(ctx context.Context, name string) (*eva.CustomOrderStatus, error) {
+	clientResponse, err := r.provider.evaClient.ListCustomOrderStatus(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found *eva.CustomOrderStatus
+
+	for i, customOrderStatus := range clientResponse.Result {
+		if customOrderStatus.Name != name {
+			continue
+		}
+
+		if found != nil {
+			return nil, errors.New(fmt.Sprintf("Both custom order status %d and %d are named %q.", found.ID, customOrderStatus.ID, name))
+		}
+
+		found = &clientResponse.Result[i]
+	}
+
+	return found, nil
+}
+
 func (r customOrderStatus) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "custom order status", func(ctx context.Context, name string) (int64, bool, error) {
+		customOrderStatus, err := r.findByName(ctx, name)
+
+		if err != nil || customOrderStatus == nil {
+			return 0, false, err
+		}
+
+		return customOrderStatus.ID, true, nil
+	})
 }
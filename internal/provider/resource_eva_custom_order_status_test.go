@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
 )
 
 func TestAccEvaCustomOrderStatusResource(t *testing.T) {
@@ -20,12 +28,19 @@ func TestAccEvaCustomOrderStatusResource(t *testing.T) {
 					resource.TestCheckResourceAttr("eva_custom_order_status.test", "description", "pending order"),
 				),
 			},
-			// // ImportState testing
-			// {
-			// 	ResourceName:      "eva_role.test",
-			// 	ImportState:       true,
-			// 	ImportStateVerify: true,
-			// },
+			// ImportState testing
+			{
+				ResourceName:      "eva_custom_order_status.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by name testing
+			{
+				ResourceName:      "eva_custom_order_status.test",
+				ImportState:       true,
+				ImportStateId:     "name:pending",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccEvaCustomOrderStatusResourceConfig("completed", "completed order"),
@@ -40,9 +55,141 @@ func TestAccEvaCustomOrderStatusResource(t *testing.T) {
 }
 
 func testAccEvaCustomOrderStatusResourceConfig(name string, description string) string {
-	return fmt.Sprintf(`
+	return testAccProviderConfig() + fmt.Sprintf(`
 resource "eva_custom_order_status" "test" {
 	name                   = "%s"
 	description            = "%s"
 }`, name, description)
 }
+
+func TestCustomOrderStatusImportByName(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	created, err := server.NewClient().CreateCustomOrderStatus(ctx, eva.CreateCustomOrderStatusRequest{Name: "pending", Description: "pending order"})
+
+	if err != nil {
+		t.Fatalf("unable to create custom order status: %s", err)
+	}
+
+	schema, _ := customOrderStatusType{}.GetSchema(ctx)
+
+	state, diags := testImportStateAndRead(ctx, customOrderStatus{provider: testProvider(server)}, schema, "name:pending")
+
+	for _, d := range diags {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var data customOrderStatusTypeData
+
+	state.Get(ctx, &data)
+
+	if data.ID.Value != created.ID || data.Name.Value != "pending" || data.Description.Value != "pending order" {
+		t.Errorf("expected custom order status %d to be imported, got %+v", created.ID, data)
+	}
+}
+
+func TestCustomOrderStatusFindByName(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	for _, req := range []eva.CreateCustomOrderStatusRequest{
+		{Name: "pending", Description: "pending order"},
+		{Name: "completed", Description: "completed order"},
+		{Name: "completed", Description: "other completed order"},
+	} {
+		if _, err := client.CreateCustomOrderStatus(ctx, req); err != nil {
+			t.Fatalf("unable to create custom order status %s: %s", req.Name, err)
+		}
+	}
+
+	r := customOrderStatus{provider: testProvider(server)}
+
+	found, err := r.findByName(ctx, "pending")
+
+	if err != nil || found == nil || found.Description != "pending order" {
+		t.Errorf("expected to find the pending custom order status, got %+v, %v", found, err)
+	}
+
+	found, err = r.findByName(ctx, "missing")
+
+	if err != nil || found != nil {
+		t.Errorf("expected no custom order status, got %+v, %v", found, err)
+	}
+
+	if _, err := r.findByName(ctx, "completed"); err == nil {
+		t.Errorf("expected an error when custom order statuses share their name")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	r = customOrderStatus{provider: provider{evaClient: *eva.NewClient(failing.URL), configured: true}}
+
+	if _, err := r.findByName(ctx, "pending"); err == nil {
+		t.Errorf("expected an error when the custom order statuses can't be listed")
+	}
+}
+
+func TestCustomOrderStatusCreateAdoptExisting(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	existing, err := client.CreateCustomOrderStatus(ctx, eva.CreateCustomOrderStatusRequest{Name: "adopted", Description: "old"})
+
+	if err != nil {
+		t.Fatalf("unable to create custom order status: %s", err)
+	}
+
+	r := customOrderStatus{provider: testProvider(server)}
+	schema, _ := customOrderStatusType{}.GetSchema(ctx)
+
+	// The existing custom order status is adopted and the one with another name is created.
+	for name, expectedID := range map[string]int64{"adopted": existing.ID, "created": 0} {
+		config := tfsdk.State{Schema: schema}
+		config.Set(ctx, &customOrderStatusTypeData{
+			ID:            types.Int64{Null: true},
+			Name:          types.String{Value: name},
+			Description:   types.String{Value: "new"},
+			AdoptExisting: types.Bool{Value: true},
+			Timeouts:      timeouts{},
+		})
+
+		resp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(ctx, tfsdk.CreateResourceRequest{Config: tfsdk.Config{Schema: schema, Raw: config.Raw}}, &resp)
+
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+		}
+
+		var data customOrderStatusTypeData
+
+		resp.State.Get(ctx, &data)
+
+		if expectedID != 0 && data.ID.Value != expectedID {
+			t.Errorf("expected custom order status %s to be adopted as %d, got %d", name, expectedID, data.ID.Value)
+		}
+
+		if expectedID == 0 && data.ID.Value == existing.ID {
+			t.Errorf("expected custom order status %s to be created, got the adopted one %d", name, data.ID.Value)
+		}
+
+		found, err := r.findByName(ctx, name)
+
+		if err != nil || found == nil || found.ID != data.ID.Value || found.Description != "new" {
+			t.Errorf("expected custom order status %s to be updated, got %+v, %v", name, found, err)
+		}
+	}
+}
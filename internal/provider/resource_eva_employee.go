@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -13,66 +14,228 @@ import (
 	"github.com/mad-it/terraform-provider-eva/internal/eva"
 )
 
+const (
+	employeeOnDestroyDelete     = "delete"
+	employeeOnDestroyDeactivate = "deactivate"
+	employeeOnDestroyDowngrade  = "downgrade"
+)
+
 type employeeType struct{}
 
 func (t employeeType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		MarkdownDescription: "Eva employee configuration.",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				MarkdownDescription: "ID of the employee.",
-				Computed:            true,
-				Type:                types.Int64Type,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.UseStateForUnknown(),
-				},
+		Attributes: employeeAttributes(),
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}, nil
+}
+
+// employeeResourceData holds the eva_employee resource, these are the fields of an employee and the timeouts of the
+// resource. The framework does not support embedded structs, so the fields are converted from and to employeeTypeData,
+// which is shared with the rows of eva_employees.
+type employeeResourceData struct {
+	ID                        types.Int64    `tfsdk:"id"`
+	FirstName                 types.String   `tfsdk:"first_name"`
+	LastName                  types.String   `tfsdk:"last_name"`
+	EmailAddress              types.String   `tfsdk:"email_address"`
+	Password                  types.String   `tfsdk:"password"`
+	Nickname                  types.String   `tfsdk:"nickname"`
+	PhoneNumber               types.String   `tfsdk:"phone_number"`
+	LanguageID                types.String   `tfsdk:"language_id"`
+	CountryID                 types.String   `tfsdk:"country_id"`
+	DateOfBirth               types.String   `tfsdk:"date_of_birth"`
+	EmployeeNumber            types.String   `tfsdk:"employee_number"`
+	BackendID                 types.String   `tfsdk:"backend_id"`
+	PrimaryOrganizationUnitID types.Int64    `tfsdk:"primary_organization_unit_id"`
+	IsActive                  types.Bool     `tfsdk:"is_active"`
+	CreateResult              types.Int64    `tfsdk:"create_result"`
+	OnDestroy                 types.String   `tfsdk:"on_destroy"`
+	Roles                     []roleTypeData `tfsdk:"roles"`
+	Timeouts                  timeouts       `tfsdk:"timeouts"`
+}
+
+func (d employeeResourceData) employee() employeeTypeData {
+	return employeeTypeData{
+		ID:                        d.ID,
+		FirstName:                 d.FirstName,
+		LastName:                  d.LastName,
+		EmailAddress:              d.EmailAddress,
+		Password:                  d.Password,
+		Nickname:                  d.Nickname,
+		PhoneNumber:               d.PhoneNumber,
+		LanguageID:                d.LanguageID,
+		CountryID:                 d.CountryID,
+		DateOfBirth:               d.DateOfBirth,
+		EmployeeNumber:            d.EmployeeNumber,
+		BackendID:                 d.BackendID,
+		PrimaryOrganizationUnitID: d.PrimaryOrganizationUnitID,
+		IsActive:                  d.IsActive,
+		CreateResult:              d.CreateResult,
+		OnDestroy:                 d.OnDestroy,
+		Roles:                     d.Roles,
+	}
+}
+
+func (d *employeeResourceData) setEmployee(employee employeeTypeData) {
+	d.ID = employee.ID
+	d.FirstName = employee.FirstName
+	d.LastName = employee.LastName
+	d.EmailAddress = employee.EmailAddress
+	d.Password = employee.Password
+	d.Nickname = employee.Nickname
+	d.PhoneNumber = employee.PhoneNumber
+	d.LanguageID = employee.LanguageID
+	d.CountryID = employee.CountryID
+	d.DateOfBirth = employee.DateOfBirth
+	d.EmployeeNumber = employee.EmployeeNumber
+	d.BackendID = employee.BackendID
+	d.PrimaryOrganizationUnitID = employee.PrimaryOrganizationUnitID
+	d.IsActive = employee.IsActive
+	d.CreateResult = employee.CreateResult
+	d.OnDestroy = employee.OnDestroy
+	d.Roles = employee.Roles
+}
+
+// employeeAttributes returns the attributes of a single employee, these are shared with the eva_employees resource.
+func employeeAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			MarkdownDescription: "ID of the employee.",
+			Computed:            true,
+			Type:                types.Int64Type,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				tfsdk.UseStateForUnknown(),
 			},
-			"first_name": {
-				Optional: true,
-				Type:     types.StringType,
+		},
+		"first_name": {
+			Optional: true,
+			Type:     types.StringType,
+		},
+		"last_name": {
+			Optional: true,
+			Type:     types.StringType,
+		},
+		"email_address": {
+			Required: true,
+			Type:     types.StringType,
+		},
+		"password": {
+			Required:  true,
+			Type:      types.StringType,
+			Sensitive: true,
+		},
+		"nickname": {
+			MarkdownDescription: "Nickname of the employee.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+		"phone_number": {
+			MarkdownDescription: "Phone number of the employee.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+		"language_id": {
+			MarkdownDescription: "Preferred language of the employee, e.g. `nl`.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+		"country_id": {
+			MarkdownDescription: "Preferred country of the employee, e.g. `NL`.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+		"date_of_birth": {
+			MarkdownDescription: "Date of birth of the employee, formatted as `YYYY-MM-DD`.",
+			Optional:            true,
+			Type:                types.StringType,
+			Validators: []tfsdk.AttributeValidator{
+				dateValidator,
 			},
-			"last_name": {
-				Optional: true,
-				Type:     types.StringType,
+		},
+		"employee_number": {
+			MarkdownDescription: "Employee number of the employee.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+		"backend_id": {
+			MarkdownDescription: "Unique reference value of the employee in an external system.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+		"primary_organization_unit_id": {
+			MarkdownDescription: "ID of the organization unit the employee primarily works at.",
+			Optional:            true,
+			Type:                types.Int64Type,
+		},
+		"is_active": {
+			MarkdownDescription: "Whether the employee is able to log in. Defaults to `true`.",
+			Optional:            true,
+			Computed:            true,
+			Type:                types.BoolType,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				boolDefaultModifier{
+					Default: true,
+				},
 			},
-			"email_address": {
-				Required: true,
-				Type:     types.StringType,
+		},
+		"create_result": {
+			MarkdownDescription: `Result of creating the employee in EVA:
+				- CreatedNewUser = 0
+				- UpgradedExistingUser = 1
+				- UpdatedExistingUser = 2
+				`,
+			Computed: true,
+			Type:     types.Int64Type,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				tfsdk.UseStateForUnknown(),
 			},
-			"password": {
-				Required:  true,
-				Type:      types.StringType,
-				Sensitive: true,
+		},
+		"on_destroy": {
+			MarkdownDescription: `What to do with the user on destroy:
+				- delete: delete the user from EVA
+				- deactivate: keep the user but deactivate it
+				- downgrade: keep the user but detach all of its employee roles
+
+				Defaults to delete for users created by Terraform, and to downgrade for existing users that were upgraded or updated to an employee.
+				`,
+			Optional: true,
+			Type:     types.StringType,
+			Validators: []tfsdk.AttributeValidator{
+				stringOneOfValidator{
+					Values: []string{employeeOnDestroyDelete, employeeOnDestroyDeactivate, employeeOnDestroyDowngrade},
+				},
 			},
-			"roles": {
-				MarkdownDescription: "list of scoped functionalities to be attached",
-				Optional:            true,
-				Attributes: tfsdk.ListNestedAttributes(
-					map[string]tfsdk.Attribute{
-						"role_id": {
-							MarkdownDescription: "id of the role",
-							Required:            true,
-							Type:                types.Int64Type,
-						},
-						"user_type": {
-							MarkdownDescription: "functionality scope",
-							Required:            true,
-							Type:                types.Int64Type,
-						},
-						"organization_unit_id": {
-							MarkdownDescription: "id of the organization unit the role applies too.",
-							Optional:            true,
-							Type:                types.Int64Type,
-						},
+		},
+		"roles": {
+			MarkdownDescription: "list of scoped functionalities to be attached",
+			Optional:            true,
+			Attributes: tfsdk.ListNestedAttributes(
+				map[string]tfsdk.Attribute{
+					"role_id": {
+						MarkdownDescription: "id of the role",
+						Required:            true,
+						Type:                types.Int64Type,
 					},
-					tfsdk.ListNestedAttributesOptions{
-						MinItems: 1,
+					"user_type": {
+						MarkdownDescription: "functionality scope",
+						Required:            true,
+						Type:                types.Int64Type,
 					},
-				),
-			},
+					"organization_unit_id": {
+						MarkdownDescription: "id of the organization unit the role applies too.",
+						Optional:            true,
+						Type:                types.Int64Type,
+					},
+				},
+				tfsdk.ListNestedAttributesOptions{
+					MinItems: 1,
+				},
+			),
 		},
-	}, nil
+	}
 }
 
 func (t employeeType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
@@ -90,12 +253,23 @@ type roleTypeData struct {
 }
 
 type employeeTypeData struct {
-	ID           types.Int64    `tfsdk:"id"`
-	FirstName    types.String   `tfsdk:"first_name"`
-	LastName     types.String   `tfsdk:"last_name"`
-	EmailAddress types.String   `tfsdk:"email_address"`
-	Password     types.String   `tfsdk:"password"`
-	Roles        []roleTypeData `tfsdk:"roles"`
+	ID                        types.Int64    `tfsdk:"id"`
+	FirstName                 types.String   `tfsdk:"first_name"`
+	LastName                  types.String   `tfsdk:"last_name"`
+	EmailAddress              types.String   `tfsdk:"email_address"`
+	Password                  types.String   `tfsdk:"password"`
+	Nickname                  types.String   `tfsdk:"nickname"`
+	PhoneNumber               types.String   `tfsdk:"phone_number"`
+	LanguageID                types.String   `tfsdk:"language_id"`
+	CountryID                 types.String   `tfsdk:"country_id"`
+	DateOfBirth               types.String   `tfsdk:"date_of_birth"`
+	EmployeeNumber            types.String   `tfsdk:"employee_number"`
+	BackendID                 types.String   `tfsdk:"backend_id"`
+	PrimaryOrganizationUnitID types.Int64    `tfsdk:"primary_organization_unit_id"`
+	IsActive                  types.Bool     `tfsdk:"is_active"`
+	CreateResult              types.Int64    `tfsdk:"create_result"`
+	OnDestroy                 types.String   `tfsdk:"on_destroy"`
+	Roles                     []roleTypeData `tfsdk:"roles"`
 }
 
 type employee struct {
@@ -116,33 +290,127 @@ func (d employeeTypeData) getEvaUserRoles() []eva.RoleOrganizationUnitSet {
 	return roles
 }
 
-func (d employeeTypeData) setUserRoles(userRoles []eva.UserRole) {
+// getDestroyAction returns what should happen to the user in EVA when the resource is destroyed.
+// Users which were not created by Terraform are only downgraded, unless configured otherwise.
+func (d employeeTypeData) getDestroyAction() string {
+	if !d.OnDestroy.Null && d.OnDestroy.Value != "" {
+		return d.OnDestroy.Value
+	}
+
+	if !d.CreateResult.Null && eva.CreateEmployeeResult(d.CreateResult.Value) == eva.CreatedNewUser {
+		return employeeOnDestroyDelete
+	}
+
+	return employeeOnDestroyDowngrade
+}
+
+func (d employeeTypeData) getEvaCreateEmployeeRequest() eva.CreateEmployeeUserRequest {
+	return eva.CreateEmployeeUserRequest{
+		FirstName:                 d.FirstName.Value,
+		LastName:                  d.LastName.Value,
+		EmailAddress:              d.EmailAddress.Value,
+		Password:                  d.Password.Value,
+		Nickname:                  d.Nickname.Value,
+		PhoneNumber:               d.PhoneNumber.Value,
+		LanguageID:                d.LanguageID.Value,
+		CountryID:                 d.CountryID.Value,
+		DateOfBirth:               d.DateOfBirth.Value,
+		EmployeeNumber:            d.EmployeeNumber.Value,
+		BackendID:                 d.BackendID.Value,
+		PrimaryOrganizationUnitID: d.PrimaryOrganizationUnitID.Value,
+	}
+}
+
+// getEvaUpdateUserRequest returns a request which updates all fields of the user, so fields which are no longer
+// configured are cleared in EVA.
+func (d employeeTypeData) getEvaUpdateUserRequest() eva.UpdateUserRequest {
+	isDeactivated := !d.IsActive.Value
+
+	return eva.UpdateUserRequest{
+		ID:                        d.ID.Value,
+		FirstName:                 d.FirstName.Value,
+		LastName:                  d.LastName.Value,
+		EmailAddress:              d.EmailAddress.Value,
+		Nickname:                  d.Nickname.Value,
+		PhoneNumber:               d.PhoneNumber.Value,
+		LanguageID:                d.LanguageID.Value,
+		CountryID:                 d.CountryID.Value,
+		DateOfBirth:               stringPointer(d.DateOfBirth),
+		EmployeeNumber:            d.EmployeeNumber.Value,
+		BackendID:                 d.BackendID.Value,
+		PrimaryOrganizationUnitID: int64Pointer(d.PrimaryOrganizationUnitID),
+		IsDeactivated:             &isDeactivated,
+	}
+}
+
+func (d *employeeTypeData) setUser(user *eva.GetEmployeeResponse) {
+	d.FirstName = types.String{Value: user.FirstName}
+	d.LastName = types.String{Value: user.LastName}
+	d.EmailAddress = types.String{Value: user.EmailAddress}
+	d.Nickname = stringValueOrNull(user.Nickname)
+	d.PhoneNumber = stringValueOrNull(user.PhoneNumber)
+	d.LanguageID = stringValueOrNull(user.LanguageID)
+	d.CountryID = stringValueOrNull(user.CountryID)
+	d.EmployeeNumber = stringValueOrNull(user.EmployeeNumber)
+	d.BackendID = stringValueOrNull(user.BackendID)
+	d.PrimaryOrganizationUnitID = int64ValueOrNull(user.PrimaryOrganizationUnitID)
+	d.DateOfBirth = dateValueOrNull(user.DateOfBirth)
+	d.IsActive = types.Bool{Value: !user.IsDeactivated}
+}
+
+func (d *employeeTypeData) setUserRoles(userRoles []eva.UserRole) {
+	d.Roles = nil
+
 	for _, userRole := range userRoles {
 		d.Roles = append(d.Roles, roleTypeData{
 			RoleID:             types.Int64{Value: userRole.RoleID},
-			OrganizationUnitID: types.Int64{Value: userRole.OrganizationUnitID},
+			OrganizationUnitID: int64ValueOrNull(userRole.OrganizationUnitID),
 			UserType:           types.Int64{Value: userRole.UserType},
 		})
 	}
+}
+
+// readEvaEmployee refreshes the employee and its roles from EVA.
+func readEvaEmployee(ctx context.Context, client *eva.Client, data *employeeTypeData) error {
+	client_resp, err := client.GetUser(ctx, eva.GetUserRequest{
+		ID: data.ID.Value,
+	})
+
+	if err != nil {
+		return err
+	}
 
+	data.setUser(client_resp)
+	// Password cannot be read, so this value is not updated in the state.
+
+	roles_client_resp, err := client.GetUserRole(ctx, eva.GetUserRoleRequest{
+		UserId: data.ID.Value,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	data.setUserRoles(roles_client_resp.Roles)
+
+	return nil
 }
 
 func (r employee) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
-	var data employeeTypeData
+	var resourceData employeeResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Config.Get(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client_resp, err := r.provider.evaClient.CreateEmployee(ctx, eva.CreateEmployeeUserRequest{
-		FirstName:    data.FirstName.Value,
-		LastName:     data.LastName.Value,
-		EmailAddress: data.EmailAddress.Value,
-		Password:     data.Password.Value,
-	})
+	ctx, cancel := resourceData.Timeouts.create(ctx)
+	defer cancel()
+
+	data := resourceData.employee()
+	client_resp, err := r.provider.evaClient.CreateEmployee(ctx, data.getEvaCreateEmployeeRequest())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Creating employee failed.", fmt.Sprintf("Unable to create employee, got error: %s", err))
@@ -150,17 +418,31 @@ func (r employee) Create(ctx context.Context, req tfsdk.CreateResourceRequest, r
 	}
 
 	data.ID = types.Int64{Value: client_resp.ID}
+	data.CreateResult = types.Int64{Value: int64(client_resp.Result)}
 	employee := employeeTypeData{
 		ID:           types.Int64{Value: client_resp.ID},
 		FirstName:    types.String{Value: data.FirstName.Value},
 		LastName:     types.String{Value: data.LastName.Value},
 		EmailAddress: types.String{Value: data.EmailAddress.Value},
 		Password:     types.String{Value: data.Password.Value},
+		CreateResult: data.CreateResult,
+		OnDestroy:    data.OnDestroy,
 	}
 
-	diags = resp.State.Set(ctx, &employee)
+	resourceData.setEmployee(employee)
+	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 
+	// Users are always created active, so deactivate afterwards when configured.
+	if !data.IsActive.Null && !data.IsActive.Value {
+		_, err = r.provider.evaClient.UpdateUser(ctx, data.getEvaUpdateUserRequest())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Deactivating employee failed.", fmt.Sprintf("Unable to deactivate employee, got error: %s", err))
+			return
+		}
+	}
+
 	_, err = r.provider.evaClient.SetUserRole(ctx, eva.SetUserRoleRequest{
 		UserId: client_resp.ID,
 		Roles:  data.getEvaUserRoles(),
@@ -173,60 +455,64 @@ func (r employee) Create(ctx context.Context, req tfsdk.CreateResourceRequest, r
 
 	tflog.Trace(ctx, "Created an employee.")
 
-	diags = resp.State.Set(ctx, &data)
+	if data.IsActive.Null {
+		data.IsActive = types.Bool{Value: true}
+	}
+
+	resourceData.setEmployee(data)
+	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r employee) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
-	var data employeeTypeData
+	var resourceData employeeResourceData
 
-	diags := req.State.Get(ctx, &data)
+	diags := req.State.Get(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client_resp, err := r.provider.evaClient.GetUser(ctx, eva.GetUserRequest{
-		ID: data.ID.Value,
-	})
+	ctx, cancel := resourceData.Timeouts.read(ctx)
+	defer cancel()
 
-	if err != nil {
-		resp.Diagnostics.AddError("Creating employee unit failed.", fmt.Sprintf("Unable to create example, got error: %s", err))
-		return
-	}
+	data := resourceData.employee()
 
-	data.FirstName = types.String{Value: client_resp.FirstName}
-	data.LastName = types.String{Value: client_resp.LastName}
-	data.EmailAddress = types.String{Value: client_resp.EmailAddress}
-	// Password cannot be read, so this value is not updated in the state.
+	err := readEvaEmployee(ctx, &r.provider.evaClient, &data)
 
-	roles_client_resp, err := r.provider.evaClient.GetUserRole(ctx, eva.GetUserRoleRequest{
-		UserId: data.ID.Value,
-	})
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The employee no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	data.setUserRoles(roles_client_resp.Roles)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading employee failed.", fmt.Sprintf("Unable to read employee, got error: %s", err))
+		return
+	}
 
-	diags = resp.State.Set(ctx, &data)
+	resourceData.setEmployee(data)
+	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r employee) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	var data employeeTypeData
+	var resourceData employeeResourceData
 
-	diags := req.Plan.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.provider.evaClient.UpdateUser(ctx, eva.UpdateUserRequest{
-		ID:           data.ID.Value,
-		FirstName:    data.FirstName.Value,
-		LastName:     data.LastName.Value,
-		EmailAddress: data.EmailAddress.Value,
-	})
+	ctx, cancel := resourceData.Timeouts.update(ctx)
+	defer cancel()
+
+	data := resourceData.employee()
+
+	_, err := r.provider.evaClient.UpdateUser(ctx, data.getEvaUpdateUserRequest())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Updating employee unit failed.", fmt.Sprintf("Unable to update employee, got error: %s", err))
@@ -245,32 +531,59 @@ func (r employee) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, r
 		return
 	}
 
-	diags = resp.State.Set(ctx, &data)
+	resourceData.setEmployee(data)
+	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r employee) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
-	var data employeeTypeData
+	var resourceData employeeResourceData
 
-	diags := req.State.Get(ctx, &data)
+	diags := req.State.Get(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.provider.evaClient.DeleteUser(ctx, eva.DeleteUserRequest{
-		ID: data.ID.Value,
-	})
+	ctx, cancel := resourceData.Timeouts.delete(ctx)
+	defer cancel()
+
+	data := resourceData.employee()
+
+	err := destroyEvaEmployee(ctx, &r.provider.evaClient, data)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Deleting employee failed.", fmt.Sprintf("Unable to delete employee, got error: %s", err))
+		resp.Diagnostics.AddError("Deleting employee failed.", fmt.Sprintf("Unable to %s employee, got error: %s", data.getDestroyAction(), err))
 		return
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
+// destroyEvaEmployee deletes, deactivates or downgrades the user, depending on the destroy action of the employee.
+func destroyEvaEmployee(ctx context.Context, client *eva.Client, data employeeTypeData) error {
+	var err error
+
+	switch data.getDestroyAction() {
+	case employeeOnDestroyDelete:
+		_, err = client.DeleteUser(ctx, eva.DeleteUserRequest{
+			ID: data.ID.Value,
+		})
+	case employeeOnDestroyDeactivate:
+		_, err = client.DeactivateUser(ctx, eva.DeactivateUserRequest{
+			ID: data.ID.Value,
+		})
+	case employeeOnDestroyDowngrade:
+		_, err = client.SetUserRole(ctx, eva.SetUserRoleRequest{
+			UserId: data.ID.Value,
+			Roles:  []eva.RoleOrganizationUnitSet{},
+		})
+	}
+
+	return err
+}
+
 func (r employee) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
 	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
 }
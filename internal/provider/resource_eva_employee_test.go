@@ -0,0 +1,434 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestEmployeeGetDestroyAction(t *testing.T) {
+	testCases := []struct {
+		name         string
+		onDestroy    types.String
+		createResult types.Int64
+		expected     string
+	}{
+		{
+			name:         "created new user",
+			onDestroy:    types.String{Null: true},
+			createResult: types.Int64{Value: int64(eva.CreatedNewUser)},
+			expected:     employeeOnDestroyDelete,
+		},
+		{
+			name:         "upgraded existing user",
+			onDestroy:    types.String{Null: true},
+			createResult: types.Int64{Value: int64(eva.UpgradedExistingUser)},
+			expected:     employeeOnDestroyDowngrade,
+		},
+		{
+			name:         "updated existing user",
+			onDestroy:    types.String{Null: true},
+			createResult: types.Int64{Value: int64(eva.UpdatedExistingUser)},
+			expected:     employeeOnDestroyDowngrade,
+		},
+		{
+			name:         "imported user",
+			onDestroy:    types.String{Null: true},
+			createResult: types.Int64{Null: true},
+			expected:     employeeOnDestroyDowngrade,
+		},
+		{
+			name:         "delete configured",
+			onDestroy:    types.String{Value: employeeOnDestroyDelete},
+			createResult: types.Int64{Value: int64(eva.UpdatedExistingUser)},
+			expected:     employeeOnDestroyDelete,
+		},
+		{
+			name:         "deactivate configured",
+			onDestroy:    types.String{Value: employeeOnDestroyDeactivate},
+			createResult: types.Int64{Value: int64(eva.CreatedNewUser)},
+			expected:     employeeOnDestroyDeactivate,
+		},
+		{
+			name:         "downgrade configured",
+			onDestroy:    types.String{Value: employeeOnDestroyDowngrade},
+			createResult: types.Int64{Value: int64(eva.CreatedNewUser)},
+			expected:     employeeOnDestroyDowngrade,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := employeeTypeData{OnDestroy: testCase.onDestroy, CreateResult: testCase.createResult}.getDestroyAction()
+
+			if actual != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDestroyEvaEmployee(t *testing.T) {
+	testCases := []struct {
+		name              string
+		onDestroy         types.String
+		existingUser      bool
+		expectDeleted     bool
+		expectDeactivated bool
+	}{
+		{
+			name:          "delete",
+			onDestroy:     types.String{Value: employeeOnDestroyDelete},
+			expectDeleted: true,
+		},
+		{
+			name:              "deactivate",
+			onDestroy:         types.String{Value: employeeOnDestroyDeactivate},
+			expectDeactivated: true,
+		},
+		{
+			name:      "downgrade",
+			onDestroy: types.String{Value: employeeOnDestroyDowngrade},
+		},
+		{
+			name:          "default for new user",
+			onDestroy:     types.String{Null: true},
+			expectDeleted: true,
+		},
+		{
+			name:         "default for existing user",
+			onDestroy:    types.String{Null: true},
+			existingUser: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			server := evatest.NewServer()
+			defer server.Close()
+
+			client := server.NewClient()
+			request := eva.CreateEmployeeUserRequest{EmailAddress: "employee@example.com"}
+
+			if testCase.existingUser {
+				if _, err := client.CreateEmployee(ctx, request); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			created, err := client.CreateEmployee(ctx, request)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			role, err := client.CreateRole(ctx, eva.CreateRoleRequest{Name: "employee", UserType: 1})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if _, err := client.SetUserRole(ctx, eva.SetUserRoleRequest{UserId: created.ID, Roles: []eva.RoleOrganizationUnitSet{{RoleID: role.ID, UserType: 1}}}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			data := employeeTypeData{
+				ID:           types.Int64{Value: created.ID},
+				OnDestroy:    testCase.onDestroy,
+				CreateResult: types.Int64{Value: int64(created.Result)},
+			}
+
+			if err := destroyEvaEmployee(ctx, client, data); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			user, err := client.GetUser(ctx, eva.GetUserRequest{ID: created.ID})
+
+			if testCase.expectDeleted {
+				if !errors.Is(err, eva.ErrNotFound) {
+					t.Errorf("expected employee to be deleted, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if user.IsDeactivated != testCase.expectDeactivated {
+				t.Errorf("expected employee deactivated: %t, got %t", testCase.expectDeactivated, user.IsDeactivated)
+			}
+
+			userRoles, err := client.GetUserRole(ctx, eva.GetUserRoleRequest{UserId: created.ID})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if expectRoles := testCase.expectDeactivated; (len(userRoles.Roles) > 0) != expectRoles {
+				t.Errorf("expected employee roles kept: %t, got %v", expectRoles, userRoles.Roles)
+			}
+		})
+	}
+}
+
+func TestEmployeeSetUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		user     eva.GetEmployeeResponse
+		expected employeeTypeData
+	}{
+		{
+			name: "optional fields not set",
+			user: eva.GetEmployeeResponse{
+				FirstName:    "Jane",
+				LastName:     "Doe",
+				EmailAddress: "jane@example.com",
+			},
+			expected: employeeTypeData{
+				FirstName:                 types.String{Value: "Jane"},
+				LastName:                  types.String{Value: "Doe"},
+				EmailAddress:              types.String{Value: "jane@example.com"},
+				Nickname:                  types.String{Null: true},
+				PhoneNumber:               types.String{Null: true},
+				LanguageID:                types.String{Null: true},
+				CountryID:                 types.String{Null: true},
+				DateOfBirth:               types.String{Null: true},
+				EmployeeNumber:            types.String{Null: true},
+				BackendID:                 types.String{Null: true},
+				PrimaryOrganizationUnitID: types.Int64{Null: true},
+				IsActive:                  types.Bool{Value: true},
+			},
+		},
+		{
+			name: "all fields set",
+			user: eva.GetEmployeeResponse{
+				FirstName:                 "Jane",
+				LastName:                  "Doe",
+				EmailAddress:              "jane@example.com",
+				Nickname:                  "jd",
+				PhoneNumber:               "0612345678",
+				LanguageID:                "nl",
+				CountryID:                 "NL",
+				DateOfBirth:               "1990-01-31T00:00:00Z",
+				EmployeeNumber:            "42",
+				BackendID:                 "jane",
+				PrimaryOrganizationUnitID: 7,
+				IsDeactivated:             true,
+			},
+			expected: employeeTypeData{
+				FirstName:                 types.String{Value: "Jane"},
+				LastName:                  types.String{Value: "Doe"},
+				EmailAddress:              types.String{Value: "jane@example.com"},
+				Nickname:                  types.String{Value: "jd"},
+				PhoneNumber:               types.String{Value: "0612345678"},
+				LanguageID:                types.String{Value: "nl"},
+				CountryID:                 types.String{Value: "NL"},
+				DateOfBirth:               types.String{Value: "1990-01-31"},
+				EmployeeNumber:            types.String{Value: "42"},
+				BackendID:                 types.String{Value: "jane"},
+				PrimaryOrganizationUnitID: types.Int64{Value: 7},
+				IsActive:                  types.Bool{Value: false},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var actual employeeTypeData
+
+			actual.setUser(&testCase.user)
+
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEmployeeGetEvaUpdateUserRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     employeeTypeData
+		expected string
+	}{
+		{
+			name: "optional fields not configured",
+			data: employeeTypeData{
+				ID:                        types.Int64{Value: 1},
+				FirstName:                 types.String{Null: true},
+				LastName:                  types.String{Null: true},
+				EmailAddress:              types.String{Value: "jane@example.com"},
+				Nickname:                  types.String{Null: true},
+				PhoneNumber:               types.String{Null: true},
+				LanguageID:                types.String{Null: true},
+				CountryID:                 types.String{Null: true},
+				DateOfBirth:               types.String{Null: true},
+				EmployeeNumber:            types.String{Null: true},
+				BackendID:                 types.String{Null: true},
+				PrimaryOrganizationUnitID: types.Int64{Null: true},
+				IsActive:                  types.Bool{Value: true},
+			},
+			expected: `{"ID":1,"FirstName":"","LastName":"","EmailAddress":"jane@example.com","Nickname":"","PhoneNumber":"","LanguageID":"","CountryID":"","DateOfBirth":null,"EmployeeNumber":"","BackendID":"","PrimaryOrganizationUnitID":null,"IsDeactivated":false}`,
+		},
+		{
+			name: "all fields configured",
+			data: employeeTypeData{
+				ID:                        types.Int64{Value: 1},
+				FirstName:                 types.String{Value: "Jane"},
+				LastName:                  types.String{Value: "Doe"},
+				EmailAddress:              types.String{Value: "jane@example.com"},
+				Nickname:                  types.String{Value: "jd"},
+				PhoneNumber:               types.String{Value: "0612345678"},
+				LanguageID:                types.String{Value: "nl"},
+				CountryID:                 types.String{Value: "NL"},
+				DateOfBirth:               types.String{Value: "1990-01-31"},
+				EmployeeNumber:            types.String{Value: "42"},
+				BackendID:                 types.String{Value: "jane"},
+				PrimaryOrganizationUnitID: types.Int64{Value: 7},
+				IsActive:                  types.Bool{Value: false},
+			},
+			expected: `{"ID":1,"FirstName":"Jane","LastName":"Doe","EmailAddress":"jane@example.com","Nickname":"jd","PhoneNumber":"0612345678","LanguageID":"nl","CountryID":"NL","DateOfBirth":"1990-01-31","EmployeeNumber":"42","BackendID":"jane","PrimaryOrganizationUnitID":7,"IsDeactivated":true}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := toJSON(testCase.data.getEvaUpdateUserRequest())
+
+			if actual != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEmployeeClearFields(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	created, err := client.CreateEmployee(ctx, eva.CreateEmployeeUserRequest{
+		EmailAddress:              "jane@example.com",
+		Nickname:                  "jd",
+		DateOfBirth:               "1990-01-31",
+		PrimaryOrganizationUnitID: 7,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data := employeeTypeData{
+		ID:                        types.Int64{Value: created.ID},
+		EmailAddress:              types.String{Value: "jane@example.com"},
+		Nickname:                  types.String{Null: true},
+		DateOfBirth:               types.String{Null: true},
+		PrimaryOrganizationUnitID: types.Int64{Null: true},
+		IsActive:                  types.Bool{Value: true},
+	}
+
+	if _, err := client.UpdateUser(ctx, data.getEvaUpdateUserRequest()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	user, err := client.GetUser(ctx, eva.GetUserRequest{ID: created.ID})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var actual employeeTypeData
+
+	actual.setUser(user)
+
+	if !actual.Nickname.Null || !actual.DateOfBirth.Null || !actual.PrimaryOrganizationUnitID.Null {
+		t.Errorf("expected cleared fields to be null, got %+v", actual)
+	}
+}
+
+func TestEmployeeCreateAndRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := employee{provider: testProvider(server)}
+	schema, _ := employeeType{}.GetSchema(ctx)
+
+	configured := employeeResourceData{
+		ID:                        types.Int64{Unknown: true},
+		FirstName:                 types.String{Value: "Jane"},
+		LastName:                  types.String{Value: "Doe"},
+		EmailAddress:              types.String{Value: "jane@example.com"},
+		Password:                  types.String{Value: "SomePassword1!"},
+		Nickname:                  types.String{Null: true},
+		PhoneNumber:               types.String{Null: true},
+		LanguageID:                types.String{Null: true},
+		CountryID:                 types.String{Null: true},
+		DateOfBirth:               types.String{Null: true},
+		EmployeeNumber:            types.String{Value: "1001"},
+		BackendID:                 types.String{Null: true},
+		PrimaryOrganizationUnitID: types.Int64{Null: true},
+		IsActive:                  types.Bool{Null: true},
+		CreateResult:              types.Int64{Unknown: true},
+		OnDestroy:                 types.String{Null: true},
+		Timeouts: timeouts{
+			{
+				Create: types.String{Value: "1h"},
+				Read:   types.String{Null: true},
+				Update: types.String{Null: true},
+				Delete: types.String{Null: true},
+			},
+		},
+	}
+
+	config := tfsdk.State{Schema: schema}
+	config.Set(ctx, &configured)
+
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, tfsdk.CreateResourceRequest{Config: tfsdk.Config{Schema: schema, Raw: config.Raw}}, &createResp)
+
+	for _, d := range createResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: createResp.State}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: createResp.State}, &readResp)
+
+	for _, d := range readResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var actual employeeResourceData
+
+	readResp.State.Get(ctx, &actual)
+
+	if actual.ID.Null || actual.ID.Unknown {
+		t.Errorf("expected the ID of the created employee, got %v", actual.ID)
+	}
+
+	if actual.FirstName.Value != "Jane" || actual.EmployeeNumber.Value != "1001" || !actual.IsActive.Value {
+		t.Errorf("expected the configured employee, got %+v", actual)
+	}
+
+	if actual.Password.Value != "SomePassword1!" {
+		t.Errorf("expected the password to be kept, got %v", actual.Password)
+	}
+
+	if len(actual.Timeouts) != 1 || actual.Timeouts[0].Create.Value != "1h" {
+		t.Errorf("expected the timeouts to be kept, got %+v", actual.Timeouts)
+	}
+}
@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+const defaultEmployeesConcurrency = 4
+
+type employeesType struct{}
+
+func (t employeesType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: `Eva employees configuration, used to provision many employees at once.
+
+		Employees are matched on their email address. Failing employees are reported separately and don't stop the other employees from being provisioned.
+		`,
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "ID of the batch of employees.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"concurrency": {
+				MarkdownDescription: fmt.Sprintf("Maximum number of employees provisioned at the same time. Defaults to `%d`.", defaultEmployeesConcurrency),
+				Optional:            true,
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					int64DefaultModifier{
+						Default: defaultEmployeesConcurrency,
+					},
+				},
+			},
+			"employees": {
+				MarkdownDescription: "List of employees, accepts the same attributes as the `eva_employee` resource. Can be filled using `csvdecode`.",
+				Required:            true,
+				Attributes: tfsdk.ListNestedAttributes(
+					employeesRowAttributes(),
+					tfsdk.ListNestedAttributesOptions{
+						MinItems: 1,
+					},
+				),
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}, nil
+}
+
+// employeesRowAttributes returns the employee attributes without the plan modifiers that copy the state.
+// Rows can move around in the list, so their IDs are matched on email address in ModifyPlan instead.
+func employeesRowAttributes() map[string]tfsdk.Attribute {
+	attributes := employeeAttributes()
+
+	for _, name := range []string{"id", "create_result"} {
+		attribute := attributes[name]
+		attribute.PlanModifiers = nil
+		attributes[name] = attribute
+	}
+
+	return attributes
+}
+
+func (t employeesType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return employees{
+		provider: provider,
+	}, diags
+}
+
+type employeesTypeData struct {
+	ID          types.String       `tfsdk:"id"`
+	Concurrency types.Int64        `tfsdk:"concurrency"`
+	Employees   []employeeTypeData `tfsdk:"employees"`
+	Timeouts    timeouts           `tfsdk:"timeouts"`
+}
+
+type employees struct {
+	provider provider
+}
+
+func (d employeesTypeData) getID() types.String {
+	var emailAddresses []string
+
+	for _, employee := range d.Employees {
+		emailAddresses = append(emailAddresses, employeeKey(employee))
+	}
+
+	sort.Strings(emailAddresses)
+
+	return types.String{Value: fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(emailAddresses, ","))))[:16]}
+}
+
+func (d employeesTypeData) getEmployeesByKey() map[string]employeeTypeData {
+	employeesByKey := map[string]employeeTypeData{}
+
+	for _, employee := range d.Employees {
+		employeesByKey[employeeKey(employee)] = employee
+	}
+
+	return employeesByKey
+}
+
+// matchState copies the IDs of the employees in the state to the employees with the same key in the plan.
+// Terraform copies the computed values of the row at the same position in the state, so employees which are not in
+// the state yet get an unknown ID again.
+func (d *employeesTypeData) matchState(state employeesTypeData) {
+	stateEmployees := state.getEmployeesByKey()
+
+	for i, employee := range d.Employees {
+		stateEmployee, ok := stateEmployees[employeeKey(employee)]
+
+		if !ok || stateEmployee.ID.Null {
+			d.Employees[i].ID = types.Int64{Unknown: true}
+			d.Employees[i].CreateResult = types.Int64{Unknown: true}
+			continue
+		}
+
+		d.Employees[i].ID = stateEmployee.ID
+		d.Employees[i].CreateResult = stateEmployee.CreateResult
+	}
+}
+
+// employeeKey returns the value rows are matched on, EVA treats email addresses case insensitive.
+func employeeKey(employee employeeTypeData) string {
+	return strings.ToLower(employee.EmailAddress.Value)
+}
+
+func employeesRowPath(index int) *tftypes.AttributePath {
+	return tftypes.NewAttributePath().WithAttributeName("employees").WithElementKeyInt(index)
+}
+
+// forEachEmployee calls f for every employee with at most concurrency calls running at the same time.
+// The returned errors have the same index as the employee they belong to.
+func forEachEmployee(concurrency int64, employees []employeeTypeData, f func(employee *employeeTypeData) error) []error {
+	errs := make([]error, len(employees))
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range employees {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			errs[i] = f(&employees[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+func (r employees) createEmployee(ctx context.Context, data *employeeTypeData) error {
+	client_resp, err := r.provider.evaClient.CreateEmployee(ctx, data.getEvaCreateEmployeeRequest())
+
+	if err != nil {
+		return fmt.Errorf("unable to create employee: %w", err)
+	}
+
+	data.ID = types.Int64{Value: client_resp.ID}
+	data.CreateResult = types.Int64{Value: int64(client_resp.Result)}
+
+	// Users are always created active, so deactivate afterwards when configured.
+	if !data.IsActive.Value {
+		if _, err := r.provider.evaClient.UpdateUser(ctx, data.getEvaUpdateUserRequest()); err != nil {
+			return fmt.Errorf("unable to deactivate employee: %w", err)
+		}
+	}
+
+	_, err = r.provider.evaClient.SetUserRole(ctx, eva.SetUserRoleRequest{
+		UserId: data.ID.Value,
+		Roles:  data.getEvaUserRoles(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to assign roles: %w", err)
+	}
+
+	return nil
+}
+
+func (r employees) updateEmployee(ctx context.Context, data *employeeTypeData) error {
+	if _, err := r.provider.evaClient.UpdateUser(ctx, data.getEvaUpdateUserRequest()); err != nil {
+		return fmt.Errorf("unable to update employee: %w", err)
+	}
+
+	_, err := r.provider.evaClient.SetUserRole(ctx, eva.SetUserRoleRequest{
+		UserId: data.ID.Value,
+		Roles:  data.getEvaUserRoles(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to assign roles: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConfig checks every email address is configured once, as employees are matched on their email address.
+func (r employees) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data employeesTypeData
+
+	// Unknown employees are validated once they are known.
+	if diags := req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("employees"), &data.Employees); diags.HasError() {
+		return
+	}
+
+	rows := map[string]int{}
+
+	for i, employee := range data.Employees {
+		if employee.EmailAddress.Unknown || employee.EmailAddress.Null {
+			continue
+		}
+
+		if row, ok := rows[employeeKey(employee)]; ok {
+			resp.Diagnostics.AddAttributeError(
+				employeesRowPath(i).WithAttributeName("email_address"),
+				"Duplicate employee.",
+				fmt.Sprintf("The email address %q is already configured for employee %d, employees are matched on their email address.", employee.EmailAddress.Value, row),
+			)
+			continue
+		}
+
+		rows[employeeKey(employee)] = i
+	}
+}
+
+func (r employees) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to match when the resource is created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan employeesTypeData
+	var state employeesTypeData
+
+	// The plan can't be matched while the list of employees is still unknown, the IDs are then left unknown.
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		return
+	}
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.matchState(state)
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r employees) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data employeesTypeData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	errs := forEachEmployee(data.Concurrency.Value, data.Employees, func(employee *employeeTypeData) error {
+		return r.createEmployee(ctx, employee)
+	})
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if data.Employees[i].ID.Unknown {
+			data.Employees[i].ID = types.Int64{Null: true}
+			data.Employees[i].CreateResult = types.Int64{Null: true}
+		}
+
+		resp.Diagnostics.AddAttributeError(employeesRowPath(i), "Creating employee failed.", fmt.Sprintf("Provisioning %s failed, got error: %s", data.Employees[i].EmailAddress.Value, err))
+	}
+
+	data.ID = data.getID()
+
+	tflog.Trace(ctx, "Created employees.")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r employees) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data employeesTypeData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
+	// Employees that failed to be created are left out, so they are planned to be created again.
+	var existingEmployees []employeeTypeData
+
+	for _, employee := range data.Employees {
+		if !employee.ID.Null {
+			existingEmployees = append(existingEmployees, employee)
+		}
+	}
+
+	data.Employees = existingEmployees
+
+	errs := forEachEmployee(data.Concurrency.Value, data.Employees, func(employee *employeeTypeData) error {
+		err := readEvaEmployee(ctx, &r.provider.evaClient, employee)
+
+		// Employees removed outside of Terraform are left out as well.
+		if errors.Is(err, eva.ErrNotFound) {
+			employee.ID = types.Int64{Null: true}
+			return nil
+		}
+
+		return err
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(employeesRowPath(i), "Getting employee failed.", fmt.Sprintf("Unable to get %s, got error: %s", data.Employees[i].EmailAddress.Value, err))
+		}
+	}
+
+	existingEmployees = nil
+
+	for _, employee := range data.Employees {
+		if !employee.ID.Null {
+			existingEmployees = append(existingEmployees, employee)
+		}
+	}
+
+	data.Employees = existingEmployees
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r employees) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan employeesTypeData
+	var state employeesTypeData
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.update(ctx)
+	defer cancel()
+
+	planEmployees := plan.getEmployeesByKey()
+	stateEmployees := state.getEmployeesByKey()
+
+	var removedEmployees []employeeTypeData
+
+	for _, employee := range state.Employees {
+		if _, ok := planEmployees[employeeKey(employee)]; !ok && !employee.ID.Null {
+			removedEmployees = append(removedEmployees, employee)
+		}
+	}
+
+	errs := forEachEmployee(plan.Concurrency.Value, removedEmployees, func(employee *employeeTypeData) error {
+		return destroyEvaEmployee(ctx, &r.provider.evaClient, *employee)
+	})
+
+	var remainingEmployees []employeeTypeData
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		remainingEmployees = append(remainingEmployees, removedEmployees[i])
+
+		resp.Diagnostics.AddAttributeError(employeesRowPath(len(plan.Employees)+len(remainingEmployees)-1), "Deleting employee failed.", fmt.Sprintf("Unable to %s %s, got error: %s", removedEmployees[i].getDestroyAction(), removedEmployees[i].EmailAddress.Value, err))
+	}
+
+	errs = forEachEmployee(plan.Concurrency.Value, plan.Employees, func(employee *employeeTypeData) error {
+		stateEmployee, ok := stateEmployees[employeeKey(*employee)]
+
+		if !ok || stateEmployee.ID.Null {
+			return r.createEmployee(ctx, employee)
+		}
+
+		employee.ID = stateEmployee.ID
+		employee.CreateResult = stateEmployee.CreateResult
+
+		if reflect.DeepEqual(*employee, stateEmployee) {
+			return nil
+		}
+
+		return r.updateEmployee(ctx, employee)
+	})
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if plan.Employees[i].ID.Unknown {
+			plan.Employees[i].ID = types.Int64{Null: true}
+			plan.Employees[i].CreateResult = types.Int64{Null: true}
+		}
+
+		resp.Diagnostics.AddAttributeError(employeesRowPath(i), "Updating employee failed.", fmt.Sprintf("Provisioning %s failed, got error: %s", plan.Employees[i].EmailAddress.Value, err))
+	}
+
+	// Keep track of employees that could not be removed, so removing them is tried again.
+	plan.Employees = append(plan.Employees, remainingEmployees...)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r employees) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data employeesTypeData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
+	errs := forEachEmployee(data.Concurrency.Value, data.Employees, func(employee *employeeTypeData) error {
+		if employee.ID.Null {
+			return nil
+		}
+
+		return destroyEvaEmployee(ctx, &r.provider.evaClient, *employee)
+	})
+
+	// Keep track of employees that could not be removed, so removing them is tried again.
+	var remainingEmployees []employeeTypeData
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		remainingEmployees = append(remainingEmployees, data.Employees[i])
+
+		resp.Diagnostics.AddAttributeError(employeesRowPath(i), "Deleting employee failed.", fmt.Sprintf("Unable to %s %s, got error: %s", data.Employees[i].getDestroyAction(), data.Employees[i].EmailAddress.Value, err))
+	}
+
+	if len(remainingEmployees) > 0 {
+		data.Employees = remainingEmployees
+
+		diags = resp.State.Set(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r employees) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStateNotImplemented(ctx, "Import the employees separately using the eva_employee resource.", resp)
+}
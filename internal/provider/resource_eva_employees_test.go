@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestEmployeesMatchState(t *testing.T) {
+	plan := employeesTypeData{
+		Employees: []employeeTypeData{
+			{EmailAddress: types.String{Value: "new@example.com"}, ID: types.Int64{Null: true}, CreateResult: types.Int64{Null: true}},
+			{EmailAddress: types.String{Value: "Moved@Example.com"}, ID: types.Int64{Value: 1}, CreateResult: types.Int64{Value: 0}},
+			{EmailAddress: types.String{Value: "failed@example.com"}, ID: types.Int64{Value: 2}, CreateResult: types.Int64{Value: 2}},
+		},
+	}
+	state := employeesTypeData{
+		Employees: []employeeTypeData{
+			{EmailAddress: types.String{Value: "failed@example.com"}, ID: types.Int64{Null: true}, CreateResult: types.Int64{Null: true}},
+			{EmailAddress: types.String{Value: "removed@example.com"}, ID: types.Int64{Value: 1}, CreateResult: types.Int64{Value: 0}},
+			{EmailAddress: types.String{Value: "moved@example.com"}, ID: types.Int64{Value: 2}, CreateResult: types.Int64{Value: 2}},
+		},
+	}
+
+	plan.matchState(state)
+
+	expected := []struct {
+		id           types.Int64
+		createResult types.Int64
+	}{
+		{id: types.Int64{Unknown: true}, createResult: types.Int64{Unknown: true}},
+		{id: types.Int64{Value: 2}, createResult: types.Int64{Value: 2}},
+		{id: types.Int64{Unknown: true}, createResult: types.Int64{Unknown: true}},
+	}
+
+	for i, employee := range plan.Employees {
+		if employee.ID != expected[i].id || employee.CreateResult != expected[i].createResult {
+			t.Errorf("expected employee %d to have ID %v and create result %v, got %v and %v", i, expected[i].id, expected[i].createResult, employee.ID, employee.CreateResult)
+		}
+	}
+}
+
+func TestEmployeesPlanInsertedRow(t *testing.T) {
+	ctx := context.Background()
+
+	prior := testEmployeesData(testEmployeeRow("first@example.com", 1), testEmployeeRow("second@example.com", 1))
+	prior.ID = types.String{Value: "batch"}
+
+	for i := range prior.Employees {
+		prior.Employees[i].ID = types.Int64{Value: int64(i + 1)}
+		prior.Employees[i].CreateResult = types.Int64{Value: 0}
+	}
+
+	// Terraform copies the computed values of the rows at the same position in the state, so the employee inserted
+	// before the first employee is proposed with the ID of the first employee.
+	proposed := testEmployeesData(testEmployeeRow("inserted@example.com", 1), testEmployeeRow("first@example.com", 1))
+	proposed.ID = prior.ID
+
+	for i := range proposed.Employees {
+		proposed.Employees[i].ID = prior.Employees[i].ID
+		proposed.Employees[i].CreateResult = prior.Employees[i].CreateResult
+	}
+
+	resp := testPlanResourceChange(t, "eva_employees", &prior, &proposed)
+
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schema, _ := employeesType{}.GetSchema(ctx)
+	planned, err := resp.PlannedState.Unmarshal(schema.TerraformType(ctx))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var plan employeesTypeData
+
+	tfsdk.Plan{Schema: schema, Raw: planned}.Get(ctx, &plan)
+
+	expected := []types.Int64{{Unknown: true}, {Value: 1}}
+
+	for i, employee := range plan.Employees {
+		if employee.ID != expected[i] {
+			t.Errorf("expected employee %s to have ID %v, got %v", employee.EmailAddress.Value, expected[i], employee.ID)
+		}
+	}
+
+	if !plan.Employees[0].CreateResult.Unknown {
+		t.Errorf("expected the inserted employee to have an unknown create result, got %v", plan.Employees[0].CreateResult)
+	}
+}
+
+func TestEmployeesValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          employeesTypeData
+		expectedPaths []*tftypes.AttributePath
+	}{
+		{
+			name: "unique email addresses",
+			data: testEmployeesData(testEmployeeRow("first@example.com", 1), testEmployeeRow("second@example.com", 1)),
+		},
+		{
+			name: "duplicate email addresses",
+			data: testEmployeesData(
+				testEmployeeRow("first@example.com", 1),
+				testEmployeeRow("second@example.com", 1),
+				testEmployeeRow("First@Example.com", 1),
+			),
+			expectedPaths: []*tftypes.AttributePath{employeesRowPath(2).WithAttributeName("email_address")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			schema, _ := employeesType{}.GetSchema(ctx)
+			state := tfsdk.State{Schema: schema}
+			state.Set(ctx, &testCase.data)
+
+			resp := tfsdk.ValidateResourceConfigResponse{}
+			employees{}.ValidateConfig(ctx, tfsdk.ValidateResourceConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+			if len(resp.Diagnostics) != len(testCase.expectedPaths) {
+				t.Fatalf("expected %d diagnostics, got %v", len(testCase.expectedPaths), resp.Diagnostics)
+			}
+
+			for i, d := range resp.Diagnostics {
+				if !d.(diag.DiagnosticWithPath).Path().Equal(testCase.expectedPaths[i]) {
+					t.Errorf("expected a diagnostic on %s, got %s: %s", testCase.expectedPaths[i], d.Summary(), d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestForEachEmployee(t *testing.T) {
+	employees := make([]employeeTypeData, 10)
+	errFailed := errors.New("failed")
+
+	var mutex sync.Mutex
+	running := 0
+	maxRunning := 0
+
+	errs := forEachEmployee(3, employees, func(employee *employeeTypeData) error {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+
+		employee.ID = types.Int64{Value: 1}
+
+		if employee == &employees[4] {
+			return errFailed
+		}
+
+		return nil
+	})
+
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 employees at the same time, got %d", maxRunning)
+	}
+
+	for i, err := range errs {
+		if expected := i == 4; (err != nil) != expected {
+			t.Errorf("expected error for employee %d: %t, got %v", i, expected, err)
+		}
+
+		if employees[i].ID.Value != 1 {
+			t.Errorf("expected employee %d to be updated", i)
+		}
+	}
+}
+
+func TestEmployeesCreateAndRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := employees{provider: testProvider(server)}
+	client := &r.provider.evaClient
+
+	role, err := client.CreateRole(ctx, eva.CreateRoleRequest{Name: "employee", UserType: 1})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	schema, _ := employeesType{}.GetSchema(ctx)
+	plan := tfsdk.Plan{Schema: schema}
+
+	data := employeesTypeData{
+		ID:          types.String{Unknown: true},
+		Concurrency: types.Int64{Value: 2},
+		Employees: []employeeTypeData{
+			testEmployeeRow("valid@example.com", role.ID),
+			testEmployeeRow("unknown-role@example.com", role.ID+100),
+		},
+	}
+
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+
+	if len(createResp.Diagnostics) != 1 || !createResp.Diagnostics[0].(diag.DiagnosticWithPath).Path().Equal(employeesRowPath(1)) {
+		t.Fatalf("expected a single error for the second employee, got %v", createResp.Diagnostics)
+	}
+
+	var created employeesTypeData
+
+	if diags := createResp.State.Get(ctx, &created); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The user of the failing row exists, so it is kept to be destroyed or updated later on.
+	for i, employee := range created.Employees {
+		if employee.ID.Null || employee.ID.Unknown {
+			t.Errorf("expected employee %d to have an ID, got %v", i, employee.ID)
+		}
+	}
+
+	// Roles changed outside of Terraform show up on read.
+	otherRole, err := client.CreateRole(ctx, eva.CreateRoleRequest{Name: "manager", UserType: 1})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.SetUserRole(ctx, eva.SetUserRoleRequest{UserId: created.Employees[0].ID.Value, Roles: []eva.RoleOrganizationUnitSet{{RoleID: otherRole.ID, UserType: 1}}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: createResp.State}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: createResp.State}, &readResp)
+
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read employeesTypeData
+
+	if diags := readResp.State.Get(ctx, &read); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expectedRoles := []roleTypeData{
+		{RoleID: types.Int64{Value: otherRole.ID}, UserType: types.Int64{Value: 1}, OrganizationUnitID: types.Int64{Null: true}},
+	}
+
+	if !reflect.DeepEqual(read.Employees[0].Roles, expectedRoles) {
+		t.Errorf("expected roles %+v, got %+v", expectedRoles, read.Employees[0].Roles)
+	}
+
+	if read.Employees[1].Roles != nil {
+		t.Errorf("expected no roles, got %+v", read.Employees[1].Roles)
+	}
+}
+
+func testEmployeesData(rows ...employeeTypeData) employeesTypeData {
+	return employeesTypeData{
+		ID:          types.String{Unknown: true},
+		Concurrency: types.Int64{Value: 2},
+		Employees:   rows,
+		Timeouts:    timeouts{},
+	}
+}
+
+func testEmployeeRow(emailAddress string, roleID int64) employeeTypeData {
+	return employeeTypeData{
+		ID:                        types.Int64{Unknown: true},
+		FirstName:                 types.String{Null: true},
+		LastName:                  types.String{Null: true},
+		EmailAddress:              types.String{Value: emailAddress},
+		Password:                  types.String{Value: "secret"},
+		Nickname:                  types.String{Null: true},
+		PhoneNumber:               types.String{Null: true},
+		LanguageID:                types.String{Null: true},
+		CountryID:                 types.String{Null: true},
+		DateOfBirth:               types.String{Null: true},
+		EmployeeNumber:            types.String{Null: true},
+		BackendID:                 types.String{Null: true},
+		PrimaryOrganizationUnitID: types.Int64{Null: true},
+		IsActive:                  types.Bool{Value: true},
+		CreateResult:              types.Int64{Unknown: true},
+		OnDestroy:                 types.String{Null: true},
+		Roles: []roleTypeData{
+			{RoleID: types.Int64{Value: roleID}, UserType: types.Int64{Value: 1}, OrganizationUnitID: types.Int64{Null: true}},
+		},
+	}
+}
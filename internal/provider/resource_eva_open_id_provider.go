@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -33,8 +36,9 @@ func (t openIdProviderType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 				Type:     types.BoolType,
 			},
 			"primary": {
-				Optional: true,
-				Type:     types.BoolType,
+				MarkdownDescription: "Whether this is the primary OpenID provider. Only one OpenID provider can be primary, either configure it here or with `eva_primary_open_id_provider`. When it is not configured, changes of the primary OpenID provider are not detected.",
+				Optional:            true,
+				Type:                types.BoolType,
 			},
 			"base_url": {
 				Required: true,
@@ -72,6 +76,61 @@ func (t openIdProviderType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 				Required: true,
 				Type:     types.Int64Type,
 			},
+			"client_secret": {
+				MarkdownDescription: "Client secret of the application at the OpenID provider. EVA does not return the secret, so changes made outside of Terraform are not detected. Once configured, the secret can be changed but not removed.",
+				Optional:            true,
+				Sensitive:           true,
+				Type:                types.StringType,
+			},
+			"scopes": {
+				MarkdownDescription: "Scopes to request in addition to `openid`, e.g. `profile` or `offline_access`.",
+				Optional:            true,
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+			},
+			"discovery_url": {
+				MarkdownDescription: "URL of the OpenID configuration of the provider, when it is not at `/.well-known/openid-configuration` of `base_url`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"role_claim": {
+				MarkdownDescription: "Claim with the roles of the user, which are mapped to the roles in EVA by their code.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"skip_discovery_validation": {
+				MarkdownDescription: "Skip checking the OpenID configuration of the provider at plan time. By default the issuer, endpoints, scopes and claims of the OpenID configuration at `discovery_url`, or `/.well-known/openid-configuration` of `base_url`, are checked when they are created or changed. When the OpenID configuration can't be fetched from where Terraform runs, this is reported as a warning.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"default_organization_units": {
+				MarkdownDescription: "Organization units users are assigned to by default, by the value of one of their claims.",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"claim": {
+							MarkdownDescription: "Claim of the user, e.g. `groups`.",
+							Required:            true,
+							Type:                types.StringType,
+						},
+						"value": {
+							MarkdownDescription: "Value the claim has to have.",
+							Required:            true,
+							Type:                types.StringType,
+						},
+						"organization_unit_id": {
+							MarkdownDescription: "ID of the organization unit.",
+							Required:            true,
+							Type:                types.Int64Type,
+						},
+					},
+					tfsdk.ListNestedAttributesOptions{},
+				),
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -84,19 +143,90 @@ func (t openIdProviderType) NewResource(ctx context.Context, in tfsdk.Provider)
 	}, diags
 }
 
+type openIdProviderOrganizationUnitData struct {
+	Claim              types.String `tfsdk:"claim"`
+	Value              types.String `tfsdk:"value"`
+	OrganizationUnitID types.Int64  `tfsdk:"organization_unit_id"`
+}
+
 type openIdProviderTypeData struct {
-	ID                types.Int64  `tfsdk:"id"`
-	BaseUrl           types.String `tfsdk:"base_url"`
-	ClientID          types.String `tfsdk:"client_id"`
-	CreateUsers       types.Bool   `tfsdk:"create_users"`
-	EmailAddressClaim types.String `tfsdk:"email_address_claim"`
-	Enabled           types.Bool   `tfsdk:"enabled"`
-	FirstNameClaim    types.String `tfsdk:"first_name_claim"`
-	LastNameClaim     types.String `tfsdk:"last_name_claim"`
-	Name              types.String `tfsdk:"name"`
-	NicknameClaim     types.String `tfsdk:"nickname_claim"`
-	Primary           types.Bool   `tfsdk:"primary"`
-	UserType          types.Int64  `tfsdk:"user_type"`
+	ID                       types.Int64                          `tfsdk:"id"`
+	BaseUrl                  types.String                         `tfsdk:"base_url"`
+	ClientID                 types.String                         `tfsdk:"client_id"`
+	ClientSecret             types.String                         `tfsdk:"client_secret"`
+	CreateUsers              types.Bool                           `tfsdk:"create_users"`
+	EmailAddressClaim        types.String                         `tfsdk:"email_address_claim"`
+	Enabled                  types.Bool                           `tfsdk:"enabled"`
+	FirstNameClaim           types.String                         `tfsdk:"first_name_claim"`
+	LastNameClaim            types.String                         `tfsdk:"last_name_claim"`
+	Name                     types.String                         `tfsdk:"name"`
+	NicknameClaim            types.String                         `tfsdk:"nickname_claim"`
+	Primary                  types.Bool                           `tfsdk:"primary"`
+	UserType                 types.Int64                          `tfsdk:"user_type"`
+	Scopes                   types.List                           `tfsdk:"scopes"`
+	DiscoveryUrl             types.String                         `tfsdk:"discovery_url"`
+	RoleClaim                types.String                         `tfsdk:"role_claim"`
+	DefaultOrganizationUnits []openIdProviderOrganizationUnitData `tfsdk:"default_organization_units"`
+	SkipDiscoveryValidation  types.Bool                           `tfsdk:"skip_discovery_validation"`
+	Timeouts                 timeouts                             `tfsdk:"timeouts"`
+}
+
+func (d openIdProviderTypeData) getEvaOrganizationUnitMappings() []eva.OpenIDProviderOrganizationUnitMapping {
+	var mappings []eva.OpenIDProviderOrganizationUnitMapping
+
+	for _, organizationUnit := range d.DefaultOrganizationUnits {
+		mappings = append(mappings, eva.OpenIDProviderOrganizationUnitMapping{
+			Claim:              organizationUnit.Claim.Value,
+			Value:              organizationUnit.Value.Value,
+			OrganizationUnitID: organizationUnit.OrganizationUnitID.Value,
+		})
+	}
+
+	return mappings
+}
+
+func (d openIdProviderTypeData) getEvaScopes() []string {
+	var scopes []string
+
+	for _, scope := range d.Scopes.Elems {
+		scopes = append(scopes, scope.(types.String).Value)
+	}
+
+	return scopes
+}
+
+// getScopesData keeps the attribute null when it is not configured and EVA returns no scopes.
+func getScopesData(current types.List, scopes []string) types.List {
+	if current.Null && len(scopes) == 0 {
+		return current
+	}
+
+	list := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+
+	for _, scope := range scopes {
+		list.Elems = append(list.Elems, types.String{Value: scope})
+	}
+
+	return list
+}
+
+// getDefaultOrganizationUnitsData keeps the attribute null when it is not configured and EVA returns no mappings.
+func getDefaultOrganizationUnitsData(current []openIdProviderOrganizationUnitData, mappings []eva.OpenIDProviderOrganizationUnitMapping) []openIdProviderOrganizationUnitData {
+	if current == nil && len(mappings) == 0 {
+		return nil
+	}
+
+	organizationUnits := []openIdProviderOrganizationUnitData{}
+
+	for _, mapping := range mappings {
+		organizationUnits = append(organizationUnits, openIdProviderOrganizationUnitData{
+			Claim:              types.String{Value: mapping.Claim},
+			Value:              types.String{Value: mapping.Value},
+			OrganizationUnitID: types.Int64{Value: mapping.OrganizationUnitID},
+		})
+	}
+
+	return organizationUnits
 }
 
 type openIdProvider struct {
@@ -113,17 +243,25 @@ func (r openIdProvider) Create(ctx context.Context, req tfsdk.CreateResourceRequ
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
 	client_resp, err := r.provider.evaClient.CreateOpenIDProvider(ctx, eva.CreateOpenIDProviderRequest{
-		BaseUrl:           data.BaseUrl.Value,
-		ClientID:          data.ClientID.Value,
-		CreateUsers:       data.CreateUsers.Value,
-		EmailAddressClaim: data.EmailAddressClaim.Value,
-		Enabled:           data.Enabled.Value,
-		FirstNameClaim:    data.FirstNameClaim.Value,
-		LastNameClaim:     data.LastNameClaim.Value,
-		Name:              data.Name.Value,
-		NicknameClaim:     data.NicknameClaim.Value,
-		UserType:          data.UserType.Value,
+		BaseUrl:                  data.BaseUrl.Value,
+		ClientID:                 data.ClientID.Value,
+		CreateUsers:              data.CreateUsers.Value,
+		EmailAddressClaim:        data.EmailAddressClaim.Value,
+		Enabled:                  data.Enabled.Value,
+		FirstNameClaim:           data.FirstNameClaim.Value,
+		LastNameClaim:            data.LastNameClaim.Value,
+		Name:                     data.Name.Value,
+		NicknameClaim:            data.NicknameClaim.Value,
+		UserType:                 data.UserType.Value,
+		ClientSecret:             data.ClientSecret.Value,
+		Scopes:                   data.getEvaScopes(),
+		DiscoveryUrl:             data.DiscoveryUrl.Value,
+		RoleClaim:                data.RoleClaim.Value,
+		OrganizationUnitMappings: data.getEvaOrganizationUnitMappings(),
 	})
 
 	if err != nil {
@@ -141,7 +279,8 @@ func (r openIdProvider) Create(ctx context.Context, req tfsdk.CreateResourceRequ
 		})
 
 		if err != nil {
-			resp.Diagnostics.AddWarning("Setting primary open Id provider failed.", fmt.Sprintf("Unable to set primary open Id provider, got error: %s", err))
+			data.Primary = types.Bool{Value: false}
+			resp.Diagnostics.AddError("Setting primary open Id provider failed.", fmt.Sprintf("Unable to set primary open Id provider, got error: %s", err))
 		}
 	}
 
@@ -159,10 +298,19 @@ func (r openIdProvider) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	client_resp, err := r.provider.evaClient.GetOpenIDProvider(ctx, eva.GetOpenIDProviderRequest{
 		ID: data.ID.Value,
 	})
 
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The OpenID provider no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Getting openIdProvider data failed.", fmt.Sprintf("Unable to get openIdProvider, got error: %s", err))
 		return
@@ -179,7 +327,17 @@ func (r openIdProvider) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 	data.NicknameClaim = types.String{Value: client_resp.NicknameClaim}
 	data.UserType = types.Int64{Value: client_resp.UserType}
 	data.Name = types.String{Value: client_resp.Name}
-	data.Primary = types.Bool{Value: client_resp.Primary}
+
+	// The primary OpenID provider is only read when it is configured here, when it is made primary by
+	// an eva_primary_open_id_provider instead it would differ from the configuration on every plan.
+	if !data.Primary.Null {
+		data.Primary = types.Bool{Value: client_resp.Primary}
+	}
+
+	data.DiscoveryUrl = stringValueOrNull(client_resp.DiscoveryUrl)
+	data.RoleClaim = stringValueOrNull(client_resp.RoleClaim)
+	data.DefaultOrganizationUnits = getDefaultOrganizationUnitsData(data.DefaultOrganizationUnits, client_resp.OrganizationUnitMappings)
+	data.Scopes = getScopesData(data.Scopes, client_resp.Scopes)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -199,19 +357,32 @@ func (r openIdProvider) Update(ctx context.Context, req tfsdk.UpdateResourceRequ
 		return
 	}
 
-	_, err := r.provider.evaClient.UpdateOpenIDProvider(ctx, eva.UpdateOpenIDProviderRequest{
-		ID:                plan.ID.Value,
-		BaseUrl:           plan.BaseUrl.Value,
-		ClientID:          plan.ClientID.Value,
-		CreateUsers:       plan.CreateUsers.Value,
-		EmailAddressClaim: plan.EmailAddressClaim.Value,
-		Enabled:           plan.Enabled.Value,
-		FirstNameClaim:    plan.FirstNameClaim.Value,
-		LastNameClaim:     plan.LastNameClaim.Value,
-		Name:              plan.Name.Value,
-		NicknameClaim:     plan.NicknameClaim.Value,
-		UserType:          plan.UserType.Value,
-	})
+	ctx, cancel := plan.Timeouts.update(ctx)
+	defer cancel()
+
+	request := eva.UpdateOpenIDProviderRequest{
+		ID:                       plan.ID.Value,
+		BaseUrl:                  plan.BaseUrl.Value,
+		ClientID:                 plan.ClientID.Value,
+		CreateUsers:              plan.CreateUsers.Value,
+		EmailAddressClaim:        plan.EmailAddressClaim.Value,
+		Enabled:                  plan.Enabled.Value,
+		FirstNameClaim:           plan.FirstNameClaim.Value,
+		LastNameClaim:            plan.LastNameClaim.Value,
+		Name:                     plan.Name.Value,
+		NicknameClaim:            plan.NicknameClaim.Value,
+		UserType:                 plan.UserType.Value,
+		Scopes:                   plan.getEvaScopes(),
+		DiscoveryUrl:             plan.DiscoveryUrl.Value,
+		RoleClaim:                plan.RoleClaim.Value,
+		OrganizationUnitMappings: plan.getEvaOrganizationUnitMappings(),
+	}
+
+	if plan.ClientSecret.Value != state.ClientSecret.Value {
+		request.ClientSecret = plan.ClientSecret.Value
+	}
+
+	_, err := r.provider.evaClient.UpdateOpenIDProvider(ctx, request)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Updating openIdProvider unit failed.", fmt.Sprintf("Unable to update openIdProvider, got error: %s", err))
@@ -224,7 +395,8 @@ func (r openIdProvider) Update(ctx context.Context, req tfsdk.UpdateResourceRequ
 		})
 
 		if err != nil {
-			resp.Diagnostics.AddWarning("Setting primary open Id provider failed.", fmt.Sprintf("Unable to set primary open Id provider, got error: %s", err))
+			plan.Primary = state.Primary
+			resp.Diagnostics.AddError("Setting primary open Id provider failed.", fmt.Sprintf("Unable to set primary open Id provider, got error: %s", err))
 		}
 	}
 
@@ -242,6 +414,9 @@ func (r openIdProvider) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.DeleteOpenIDProvider(ctx, eva.DeleteOpenIDProviderRequest{
 		ID: data.ID.Value,
 	})
@@ -254,6 +429,116 @@ func (r openIdProvider) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 	resp.State.RemoveResource(ctx)
 }
 
+func (r openIdProvider) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to validate when the OpenID provider is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan openIdProviderTypeData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateDiscovery := !plan.SkipDiscoveryValidation.Value
+
+	if !req.State.Raw.IsNull() {
+		var state openIdProviderTypeData
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// EVA keeps the client secret when none is sent, so it can't be removed by no longer configuring it.
+		if !state.ClientSecret.Null && plan.ClientSecret.Null {
+			resp.Diagnostics.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName("client_secret"),
+				"Client secret can't be removed.",
+				"EVA keeps the client secret of an OpenID provider when no new secret is configured. Configure another client secret, or recreate the OpenID provider to remove it.",
+			)
+		}
+
+		// The OpenID configuration was checked already, unless the configuration it is checked against changes.
+		validateDiscovery = validateDiscovery && plan.discoveryChanged(state)
+	}
+
+	if validateDiscovery {
+		resp.Diagnostics.Append(plan.validateDiscoveryDocument(ctx, r.provider.httpClient())...)
+	}
+
+	claimant := fmt.Sprintf("The eva_open_id_provider with base URL %q and client ID %q", plan.BaseUrl.Value, plan.ClientID.Value)
+
+	if !plan.Primary.Value {
+		r.provider.primaryOpenIdProviders.release(claimant)
+		return
+	}
+
+	key := fmt.Sprintf("%s %s", plan.BaseUrl.Value, plan.ClientID.Value)
+
+	if !plan.ID.Null && !plan.ID.Unknown {
+		key = fmt.Sprint(plan.ID.Value)
+	}
+
+	if other, ok := r.provider.primaryOpenIdProviders.claim(key, claimant); !ok {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("primary"),
+			"Multiple primary OpenID providers.",
+			fmt.Sprintf("Only one OpenID provider can be primary. %s is primary already, setting this OpenID provider as primary as well makes them take the primary role from each other on every apply.", other),
+		)
+	}
+}
+
 func (r openIdProvider) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
 	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
 }
+
+// primaryOpenIdProviders are the OpenID providers that are planned to be primary by the resources of this provider,
+// by their ID or, when the ID is not known yet, by their base URL and client ID. The provider is configured for every
+// plan, which starts with no claims.
+type primaryOpenIdProviders struct {
+	mutex sync.Mutex
+	// keys are the OpenID providers planned to be primary, by the resource that planned them to be primary.
+	keys map[string]string
+}
+
+func newPrimaryOpenIdProviders() *primaryOpenIdProviders {
+	return &primaryOpenIdProviders{keys: map[string]string{}}
+}
+
+// claim plans the OpenID provider with the key to be primary by the claimant. When another resource planned another
+// OpenID provider to be primary already, the claim fails and the other claimant is returned.
+func (p *primaryOpenIdProviders) claim(key string, claimant string) (string, bool) {
+	if p == nil {
+		return "", true
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for other, otherKey := range p.keys {
+		if other != claimant && otherKey != key {
+			return other, false
+		}
+	}
+
+	p.keys[claimant] = key
+
+	return "", true
+}
+
+// release removes the claim of the claimant, when its resource no longer plans an OpenID provider to be primary.
+func (p *primaryOpenIdProviders) release(claimant string) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.keys, claimant)
+}
@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestOpenIdProviderClaimPrimary(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []string
+		expected bool
+	}{
+		{
+			name:     "one OpenID provider",
+			keys:     []string{"1"},
+			expected: true,
+		},
+		{
+			name:     "same OpenID provider claimed twice",
+			keys:     []string{"1", "1"},
+			expected: true,
+		},
+		{
+			name:     "multiple OpenID providers",
+			keys:     []string{"1", "https://login.example.com client-id"},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			primaryOpenIdProviders := newPrimaryOpenIdProviders()
+			actual := true
+
+			for _, key := range testCase.keys {
+				_, ok := primaryOpenIdProviders.claim(key, key)
+				actual = actual && ok
+			}
+
+			if actual != testCase.expected {
+				t.Errorf("expected claims to succeed: %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestOpenIdProviderClaimPrimaryByResources(t *testing.T) {
+	primaryOpenIdProviders := newPrimaryOpenIdProviders()
+
+	if _, ok := primaryOpenIdProviders.claim("1", "eva_open_id_provider"); !ok {
+		t.Fatal("expected the first claim to succeed")
+	}
+
+	// Another resource can plan the same OpenID provider to be primary.
+	if _, ok := primaryOpenIdProviders.claim("1", "eva_primary_open_id_provider"); !ok {
+		t.Error("expected the claim of the same OpenID provider to succeed")
+	}
+
+	// The same resource can plan another OpenID provider to be primary, as long as no other resource claimed one.
+	primaryOpenIdProviders.release("eva_primary_open_id_provider")
+
+	if _, ok := primaryOpenIdProviders.claim("2", "eva_open_id_provider"); !ok {
+		t.Error("expected the claim of another OpenID provider by the same resource to succeed")
+	}
+
+	if other, ok := primaryOpenIdProviders.claim("3", "eva_primary_open_id_provider"); ok || other != "eva_open_id_provider" {
+		t.Errorf("expected the claim of a third OpenID provider to fail because of eva_open_id_provider, got %q", other)
+	}
+
+	primaryOpenIdProviders.release("eva_open_id_provider")
+
+	if _, ok := primaryOpenIdProviders.claim("3", "eva_primary_open_id_provider"); !ok {
+		t.Error("expected the claim to succeed once the other claim is released")
+	}
+}
+
+func TestOpenIdProviderValidateDiscoveryDocument(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document func(issuer string) map[string]interface{}
+		data     func(baseUrl string) openIdProviderTypeData
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			document: func(issuer string) map[string]interface{} {
+				return map[string]interface{}{
+					"issuer":                 issuer,
+					"authorization_endpoint": issuer + "/authorize",
+					"token_endpoint":         issuer + "/token",
+					"jwks_uri":               issuer + "/keys",
+					"scopes_supported":       []string{"openid", "profile", "email"},
+					"claims_supported":       []string{"given_name", "family_name", "email"},
+				}
+			},
+			data: func(baseUrl string) openIdProviderTypeData {
+				return openIdProviderTypeData{
+					BaseUrl:           types.String{Value: baseUrl + "/"},
+					DiscoveryUrl:      types.String{Null: true},
+					Scopes:            testStringList("profile"),
+					FirstNameClaim:    types.String{Value: "given_name"},
+					LastNameClaim:     types.String{Value: "family_name"},
+					EmailAddressClaim: types.String{Value: "email"},
+				}
+			},
+		},
+		{
+			name: "invalid",
+			document: func(issuer string) map[string]interface{} {
+				return map[string]interface{}{
+					"issuer":                 "https://login.example.com",
+					"authorization_endpoint": issuer + "/authorize",
+					"token_endpoint":         "/token",
+					"scopes_supported":       []string{"openid"},
+					"claims_supported":       []string{"email"},
+				}
+			},
+			data: func(baseUrl string) openIdProviderTypeData {
+				return openIdProviderTypeData{
+					BaseUrl:      types.String{Value: baseUrl},
+					DiscoveryUrl: types.String{Null: true},
+					Scopes:       testStringList("offline_access"),
+					RoleClaim:    types.String{Value: "roles"},
+					DefaultOrganizationUnits: []openIdProviderOrganizationUnitData{
+						{Claim: types.String{Value: "groups"}},
+					},
+				}
+			},
+			errors:   []string{"Issuer does not match.", "Invalid OpenID configuration.", "Invalid OpenID configuration."},
+			warnings: []string{"Scope not supported.", "Claim not supported.", "Claim not supported."},
+		},
+		{
+			name: "custom discovery URL not found",
+			document: func(issuer string) map[string]interface{} {
+				return nil
+			},
+			data: func(baseUrl string) openIdProviderTypeData {
+				return openIdProviderTypeData{
+					BaseUrl:      types.String{Value: baseUrl},
+					DiscoveryUrl: types.String{Value: baseUrl + "/v2.0/.well-known/openid-configuration"},
+				}
+			},
+			warnings: []string{"OpenID configuration not found."},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var server *httptest.Server
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != openIdConfigurationPath {
+					http.NotFound(w, r)
+					return
+				}
+
+				json.NewEncoder(w).Encode(testCase.document(server.URL))
+			}))
+			defer server.Close()
+
+			diags := testCase.data(server.URL).validateDiscoveryDocument(context.Background(), server.Client())
+
+			var errors []string
+			var warnings []string
+
+			for _, d := range diags {
+				if d.Severity() == diag.SeverityError {
+					errors = append(errors, d.Summary())
+				} else {
+					warnings = append(warnings, d.Summary())
+				}
+			}
+
+			if !reflect.DeepEqual(errors, testCase.errors) {
+				t.Errorf("expected errors %v, got %v", testCase.errors, errors)
+			}
+
+			if !reflect.DeepEqual(warnings, testCase.warnings) {
+				t.Errorf("expected warnings %v, got %v", testCase.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestOpenIdProviderModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := openIdProvider{provider: testProvider(server)}
+	schema, _ := openIdProviderType{}.GetSchema(ctx)
+
+	testCases := []struct {
+		name          string
+		state         func(data openIdProviderTypeData) openIdProviderTypeData
+		plan          func(data openIdProviderTypeData) openIdProviderTypeData
+		expectedPaths []*tftypes.AttributePath
+	}{
+		{
+			name: "unknown scopes",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.Scopes = types.List{ElemType: types.StringType, Unknown: true}
+				return data
+			},
+		},
+		{
+			name: "unknown scope",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.Scopes = types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Unknown: true}}}
+				return data
+			},
+		},
+		{
+			name: "client secret changed",
+			state: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.ClientSecret = types.String{Value: "secret"}
+				return data
+			},
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.ClientSecret = types.String{Value: "other secret"}
+				return data
+			},
+		},
+		{
+			name: "client secret removed",
+			state: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.ClientSecret = types.String{Value: "secret"}
+				return data
+			},
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				return data
+			},
+			expectedPaths: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("client_secret")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.TerraformType(ctx), nil)}
+
+			if testCase.state != nil {
+				stateData := testCase.state(testOpenIdProviderData())
+				state.Set(ctx, &stateData)
+			}
+
+			planData := testCase.plan(testOpenIdProviderData())
+			plan := tfsdk.Plan{Schema: schema}
+			plan.Set(ctx, &planData)
+
+			resp := tfsdk.ModifyResourcePlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, tfsdk.ModifyResourcePlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, Plan: plan, State: state}, &resp)
+
+			if len(resp.Diagnostics) != len(testCase.expectedPaths) {
+				t.Fatalf("expected %d diagnostics, got %v", len(testCase.expectedPaths), resp.Diagnostics)
+			}
+
+			for i, d := range resp.Diagnostics {
+				withPath, ok := d.(interface{ Path() *tftypes.AttributePath })
+
+				if !ok || !withPath.Path().Equal(testCase.expectedPaths[i]) {
+					t.Errorf("expected a diagnostic on %s, got %s: %s", testCase.expectedPaths[i], d.Summary(), d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestOpenIdProviderGetScopesData(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  types.List
+		scopes   []string
+		expected types.List
+	}{
+		{
+			name:     "not configured",
+			current:  types.List{ElemType: types.StringType, Null: true},
+			expected: types.List{ElemType: types.StringType, Null: true},
+		},
+		{
+			name:     "not configured with scopes in EVA",
+			current:  types.List{ElemType: types.StringType, Null: true},
+			scopes:   []string{"profile"},
+			expected: testStringList("profile"),
+		},
+		{
+			name:     "configured without scopes",
+			current:  testStringList(),
+			expected: testStringList(),
+		},
+		{
+			name:     "configured",
+			current:  testStringList("profile"),
+			scopes:   []string{"profile", "offline_access"},
+			expected: testStringList("profile", "offline_access"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := getScopesData(testCase.current, testCase.scopes); !actual.Equal(testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+// testOpenIdProviderData returns an OpenID provider which skips the discovery validation, so no OpenID provider
+// has to be reachable.
+func testOpenIdProviderData() openIdProviderTypeData {
+	return openIdProviderTypeData{
+		ID:                      types.Int64{Value: 1},
+		BaseUrl:                 types.String{Value: "https://login.example.com"},
+		ClientID:                types.String{Value: "client-id"},
+		ClientSecret:            types.String{Null: true},
+		CreateUsers:             types.Bool{Value: true},
+		EmailAddressClaim:       types.String{Null: true},
+		Enabled:                 types.Bool{Value: true},
+		FirstNameClaim:          types.String{Null: true},
+		LastNameClaim:           types.String{Null: true},
+		Name:                    types.String{Value: "Login"},
+		NicknameClaim:           types.String{Null: true},
+		Primary:                 types.Bool{Null: true},
+		UserType:                types.Int64{Value: 1},
+		Scopes:                  types.List{ElemType: types.StringType, Null: true},
+		DiscoveryUrl:            types.String{Null: true},
+		RoleClaim:               types.String{Null: true},
+		SkipDiscoveryValidation: types.Bool{Value: true},
+		Timeouts:                timeouts{},
+	}
+}
+
+func TestOpenIdProviderValidateDiscoveryDocumentTimeout(t *testing.T) {
+	done := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	data := testOpenIdProviderData()
+	data.BaseUrl = types.String{Value: server.URL}
+
+	p := provider{requestTimeout: 50 * time.Millisecond}
+	start := time.Now()
+
+	diags := data.validateDiscoveryDocument(context.Background(), p.httpClient())
+
+	if len(diags) != 1 || diags[0].Severity() != diag.SeverityWarning || diags[0].Summary() != "OpenID configuration not found." {
+		t.Errorf("expected a warning that the OpenID configuration was not found, got %v", diags)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected getting the OpenID configuration to stop after the request timeout, took %s", elapsed)
+	}
+}
+
+func TestOpenIdProviderModifyPlanValidatesChangedDiscovery(t *testing.T) {
+	ctx := context.Background()
+
+	requests := 0
+
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer discovery.Close()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := openIdProvider{provider: testProvider(server)}
+	schema, _ := openIdProviderType{}.GetSchema(ctx)
+
+	state := testOpenIdProviderData()
+	state.BaseUrl = types.String{Value: discovery.URL}
+	state.SkipDiscoveryValidation = types.Bool{Null: true}
+
+	testCases := []struct {
+		name             string
+		plan             func(data openIdProviderTypeData) openIdProviderTypeData
+		expectedRequests int
+	}{
+		{
+			name: "nothing changed",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				return data
+			},
+		},
+		{
+			name: "other attribute changed",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.Name = types.String{Value: "Other"}
+				return data
+			},
+		},
+		{
+			name: "scopes changed",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.Scopes = testStringList("profile")
+				return data
+			},
+			expectedRequests: 1,
+		},
+		{
+			name: "claim changed",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.RoleClaim = types.String{Value: "roles"}
+				return data
+			},
+			expectedRequests: 1,
+		},
+		{
+			name: "claim changed with validation skipped",
+			plan: func(data openIdProviderTypeData) openIdProviderTypeData {
+				data.RoleClaim = types.String{Value: "roles"}
+				data.SkipDiscoveryValidation = types.Bool{Value: true}
+				return data
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requests = 0
+
+			plan := testCase.plan(state)
+			diags := testModifyPlan(ctx, r, schema, state, plan)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if requests != testCase.expectedRequests {
+				t.Errorf("expected %d requests for the OpenID configuration, got %d", testCase.expectedRequests, requests)
+			}
+		})
+	}
+}
@@ -2,12 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/mad-it/terraform-provider-eva/internal/eva"
@@ -38,6 +38,14 @@ func (t orderLedgerTypeSchema) GetSchema(ctx context.Context) (tfsdk.Schema, dia
 				Required:            true,
 				Type:                types.StringType,
 			},
+			"adopt_existing": {
+				MarkdownDescription: "Adopt an existing order ledger type with the same name on create, instead of failing to create it. The adopted order ledger type is updated to the configured values.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -50,9 +58,11 @@ func (t orderLedgerTypeSchema) NewResource(ctx context.Context, in tfsdk.Provide
 }
 
 type orderLedgerTypeData struct {
-	ID          types.Int64 `tfsdk:"id"`
-	Name        string      `tfsdk:"name"`
-	Description string      `tfsdk:"description"`
+	ID            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+	Timeouts      timeouts     `tfsdk:"timeouts"`
 }
 
 type orderLedgerType struct {
@@ -69,9 +79,42 @@ func (r orderLedgerType) Create(ctx context.Context, req tfsdk.CreateResourceReq
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	if data.AdoptExisting.Value {
+		existing, err := r.findByName(ctx, data.Name.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Adopting order ledger type failed.", fmt.Sprintf("Unable to find order ledger type, got error: %s", err))
+			return
+		}
+
+		if existing != nil {
+			_, err := r.provider.evaClient.UpdateOrderLedgerType(ctx, eva.UpdateOrderLedgerTypeRequest{
+				ID:          existing.ID,
+				Name:        data.Name.Value,
+				Description: data.Description.Value,
+			})
+
+			if err != nil {
+				resp.Diagnostics.AddError("Adopting order ledger type failed.", fmt.Sprintf("Unable to update order ledger type, got error: %s", err))
+				return
+			}
+
+			data.ID = types.Int64{Value: existing.ID}
+
+			tflog.Info(ctx, "Adopted an existing order ledger type.", "id", existing.ID)
+
+			diags = resp.State.Set(ctx, &data)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
 	clientResponse, err := r.provider.evaClient.CreateOrderLedgerType(ctx, eva.CreateOrderLedgerTypeRequest{
-		Name:        data.Name,
-		Description: data.Description,
+		Name:        data.Name.Value,
+		Description: data.Description.Value,
 	})
 
 	if err != nil {
@@ -97,6 +140,9 @@ func (r orderLedgerType) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	clientResponse, err := r.provider.evaClient.ListOrderLedgerTypes(ctx)
 
 	if err != nil {
@@ -108,15 +154,16 @@ func (r orderLedgerType) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 
 	for _, orderLedgerType := range clientResponse.Result {
 		if orderLedgerType.ID == data.ID.Value {
-			data.Name = orderLedgerType.Name
-			data.Description = orderLedgerType.Description
+			data.Name = types.String{Value: orderLedgerType.Name}
+			data.Description = types.String{Value: orderLedgerType.Description}
 			orderLedgerTypeFound = true
 			break
 		}
 	}
 
 	if !orderLedgerTypeFound {
-		resp.Diagnostics.AddError("Could not find order ledger type data.", fmt.Sprintf("Unable to get order ledger type with ID: %d", data.ID.Value))
+		tflog.Info(ctx, "The order ledger type no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -134,10 +181,13 @@ func (r orderLedgerType) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 		return
 	}
 
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.UpdateOrderLedgerType(ctx, eva.UpdateOrderLedgerTypeRequest{
 		ID:          data.ID.Value,
-		Name:        data.Name,
-		Description: data.Description,
+		Name:        data.Name.Value,
+		Description: data.Description.Value,
 	})
 
 	if err != nil {
@@ -159,6 +209,9 @@ func (r orderLedgerType) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.DeleteOrderLedgerType(ctx, eva.DeleteOrderLedgerTypeRequest{
 		ID: data.ID.Value,
 	})
@@ -171,6 +224,39 @@ func (r orderLedgerType) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 	resp.State.RemoveResource(ctx)
 }
 
+// findByName returns the order ledger type with the name, or nil when there is none.
+func (r orderLedgerType) findByName(ctx context.Context, name string) (*eva.OrderLedgerType, error) {
+	clientResponse, err := r.provider.evaClient.ListOrderLedgerTypes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found *eva.OrderLedgerType
+
+	for i, orderLedgerType := range clientResponse.Result {
+		if orderLedgerType.Name != name {
+			continue
+		}
+
+		if found != nil {
+			return nil, errors.New(fmt.Sprintf("Both order ledger type %d and %d are named %q.", found.ID, orderLedgerType.ID, name))
+		}
+
+		found = &clientResponse.Result[i]
+	}
+
+	return found, nil
+}
+
 func (r orderLedgerType) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "order ledger type", func(ctx context.Context, name string) (int64, bool, error) {
+		orderLedgerType, err := r.findByName(ctx, name)
+
+		if err != nil || orderLedgerType == nil {
+			return 0, false, err
+		}
+
+		return orderLedgerType.ID, true, nil
+	})
 }
@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
 )
 
 func TestAccEvaOrderLedgerTypeResource(t *testing.T) {
@@ -20,12 +28,19 @@ func TestAccEvaOrderLedgerTypeResource(t *testing.T) {
 					resource.TestCheckResourceAttr("eva_order_ledger_type.test", "description", "pending order"),
 				),
 			},
-			// // ImportState testing
-			// {
-			// 	ResourceName:      "eva_order_ledger_type.test",
-			// 	ImportState:       true,
-			// 	ImportStateVerify: true,
-			// },
+			// ImportState testing
+			{
+				ResourceName:      "eva_order_ledger_type.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by name testing
+			{
+				ResourceName:      "eva_order_ledger_type.test",
+				ImportState:       true,
+				ImportStateId:     "name:pending",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccEvaOrderLedgerTypeResourceConfig("completed", "completed order"),
@@ -40,9 +55,141 @@ func TestAccEvaOrderLedgerTypeResource(t *testing.T) {
 }
 
 func testAccEvaOrderLedgerTypeResourceConfig(name string, description string) string {
-	return fmt.Sprintf(`
+	return testAccProviderConfig() + fmt.Sprintf(`
 resource "eva_order_ledger_type" "test" {
 	name                   = "%s"
 	description            = "%s"
 }`, name, description)
 }
+
+func TestOrderLedgerTypeImportByName(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	created, err := server.NewClient().CreateOrderLedgerType(ctx, eva.CreateOrderLedgerTypeRequest{Name: "pending", Description: "pending order"})
+
+	if err != nil {
+		t.Fatalf("unable to create order ledger type: %s", err)
+	}
+
+	schema, _ := orderLedgerTypeSchema{}.GetSchema(ctx)
+
+	state, diags := testImportStateAndRead(ctx, orderLedgerType{provider: testProvider(server)}, schema, "name:pending")
+
+	for _, d := range diags {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var data orderLedgerTypeData
+
+	state.Get(ctx, &data)
+
+	if data.ID.Value != created.ID || data.Name.Value != "pending" || data.Description.Value != "pending order" {
+		t.Errorf("expected order ledger type %d to be imported, got %+v", created.ID, data)
+	}
+}
+
+func TestOrderLedgerTypeFindByName(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	for _, req := range []eva.CreateOrderLedgerTypeRequest{
+		{Name: "pending", Description: "pending order"},
+		{Name: "completed", Description: "completed order"},
+		{Name: "completed", Description: "other completed order"},
+	} {
+		if _, err := client.CreateOrderLedgerType(ctx, req); err != nil {
+			t.Fatalf("unable to create order ledger type %s: %s", req.Name, err)
+		}
+	}
+
+	r := orderLedgerType{provider: testProvider(server)}
+
+	found, err := r.findByName(ctx, "pending")
+
+	if err != nil || found == nil || found.Description != "pending order" {
+		t.Errorf("expected to find the pending order ledger type, got %+v, %v", found, err)
+	}
+
+	found, err = r.findByName(ctx, "missing")
+
+	if err != nil || found != nil {
+		t.Errorf("expected no order ledger type, got %+v, %v", found, err)
+	}
+
+	if _, err := r.findByName(ctx, "completed"); err == nil {
+		t.Errorf("expected an error when order ledger types share their name")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	r = orderLedgerType{provider: provider{evaClient: *eva.NewClient(failing.URL), configured: true}}
+
+	if _, err := r.findByName(ctx, "pending"); err == nil {
+		t.Errorf("expected an error when the order ledger types can't be listed")
+	}
+}
+
+func TestOrderLedgerTypeCreateAdoptExisting(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	existing, err := client.CreateOrderLedgerType(ctx, eva.CreateOrderLedgerTypeRequest{Name: "adopted", Description: "old"})
+
+	if err != nil {
+		t.Fatalf("unable to create order ledger type: %s", err)
+	}
+
+	r := orderLedgerType{provider: testProvider(server)}
+	schema, _ := orderLedgerTypeSchema{}.GetSchema(ctx)
+
+	// The existing order ledger type is adopted and the one with another name is created.
+	for name, expectedID := range map[string]int64{"adopted": existing.ID, "created": 0} {
+		plan := tfsdk.Plan{Schema: schema}
+		plan.Set(ctx, &orderLedgerTypeData{
+			ID:            types.Int64{Unknown: true},
+			Name:          types.String{Value: name},
+			Description:   types.String{Value: "new"},
+			AdoptExisting: types.Bool{Value: true},
+			Timeouts:      timeouts{},
+		})
+
+		resp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &resp)
+
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+		}
+
+		var data orderLedgerTypeData
+
+		resp.State.Get(ctx, &data)
+
+		if expectedID != 0 && data.ID.Value != expectedID {
+			t.Errorf("expected order ledger type %s to be adopted as %d, got %d", name, expectedID, data.ID.Value)
+		}
+
+		if expectedID == 0 && data.ID.Value == existing.ID {
+			t.Errorf("expected order ledger type %s to be created, got the adopted one %d", name, data.ID.Value)
+		}
+
+		found, err := r.findByName(ctx, name)
+
+		if err != nil || found == nil || found.ID != data.ID.Value || found.Description != "new" {
+			t.Errorf("expected order ledger type %s to be updated, got %+v, %v", name, found, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -33,14 +34,17 @@ func (t organizationUnitType) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 				Type:                types.StringType,
 			},
 			"parent_id": {
-				MarkdownDescription: "ID of the parent shop",
+				MarkdownDescription: "ID of the parent shop. Changing the parent moves the shop in the organization unit hierarchy.",
 				Required:            true,
 				Type:                types.Int64Type,
 			},
 			"currency_id": {
-				MarkdownDescription: "Currency of the shop",
+				MarkdownDescription: "Currency of the shop. Changing the currency forces a new shop to be created.",
 				Required:            true,
 				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
 			},
 			"phone_number": {
 				MarkdownDescription: "Phone number of the shop",
@@ -79,6 +83,31 @@ func (t organizationUnitType) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 				Optional: true,
 				Type:     types.Int64Type,
 			},
+			"time_zone": {
+				MarkdownDescription: "Time zone of the shop, e.g. `Europe/Amsterdam`",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"gln": {
+				MarkdownDescription: "Global Location Number of the shop",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"vat_number": {
+				MarkdownDescription: "VAT number of the shop",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"registration_number": {
+				MarkdownDescription: "Chamber of commerce registration number of the shop",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"subnet": {
+				MarkdownDescription: "IP address or subnet, e.g. `10.0.0.0/24`, logins for the shop are restricted to",
+				Optional:            true,
+				Type:                types.StringType,
+			},
 			"address": {
 				MarkdownDescription: "Address information of the shop",
 				Optional:            true,
@@ -128,6 +157,73 @@ func (t organizationUnitType) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 				),
 			},
 		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+			"opening_hours": {
+				MarkdownDescription: "Regular opening hours of the shop per day of the week, each day can be configured once",
+				NestingMode:         tfsdk.BlockNestingModeSet,
+				MaxItems:            int64(len(weekdays)),
+				Attributes: map[string]tfsdk.Attribute{
+					"day": {
+						MarkdownDescription: "Day of the week, e.g. `monday`",
+						Required:            true,
+						Type:                types.StringType,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOfValidator{
+								Values: weekdays,
+							},
+						},
+					},
+					"ranges": {
+						MarkdownDescription: "Time ranges the shop is open on this day",
+						Required:            true,
+						// The framework does not support blocks nested in blocks yet.
+						Attributes: tfsdk.SetNestedAttributes(
+							map[string]tfsdk.Attribute{
+								"start_time": {
+									MarkdownDescription: "Opening time, formatted as `HH:MM`",
+									Required:            true,
+									Type:                types.StringType,
+									Validators: []tfsdk.AttributeValidator{
+										timeOfDayValidator,
+									},
+								},
+								"end_time": {
+									MarkdownDescription: "Closing time, formatted as `HH:MM`",
+									Required:            true,
+									Type:                types.StringType,
+									Validators: []tfsdk.AttributeValidator{
+										timeOfDayValidator,
+									},
+								},
+							},
+							tfsdk.SetNestedAttributesOptions{
+								MinItems: 1,
+							},
+						),
+					},
+				},
+			},
+			"exceptions": {
+				MarkdownDescription: "Dates the shop is closed, e.g. holidays",
+				NestingMode:         tfsdk.BlockNestingModeSet,
+				Attributes: map[string]tfsdk.Attribute{
+					"date": {
+						MarkdownDescription: "Date the shop is closed, formatted as `YYYY-MM-DD`",
+						Required:            true,
+						Type:                types.StringType,
+						Validators: []tfsdk.AttributeValidator{
+							dateValidator,
+						},
+					},
+					"description": {
+						MarkdownDescription: "Reason the shop is closed",
+						Optional:            true,
+						Type:                types.StringType,
+					},
+				},
+			},
+		},
 	}, nil
 }
 
@@ -149,16 +245,138 @@ type address struct {
 	Latitude    types.Float64 `tfsdk:"latitude"`
 	Longitude   types.Float64 `tfsdk:"longitude"`
 }
+
+// weekdays is ordered the same as the DayOfWeek values of EVA.
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+type openingHoursRange struct {
+	StartTime types.String `tfsdk:"start_time"`
+	EndTime   types.String `tfsdk:"end_time"`
+}
+
+type openingHours struct {
+	Day    types.String        `tfsdk:"day"`
+	Ranges []openingHoursRange `tfsdk:"ranges"`
+}
+
+type openingHoursException struct {
+	Date        types.String `tfsdk:"date"`
+	Description types.String `tfsdk:"description"`
+}
+
 type organizationUnitData struct {
-	Id           types.Int64  `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	PhoneNumber  types.String `tfsdk:"phone_number"`
-	EmailAddress types.String `tfsdk:"email_address"`
-	CurrencyId   types.String `tfsdk:"currency_id"`
-	ParentId     types.Int64  `tfsdk:"parent_id"`
-	BackendId    types.String `tfsdk:"backend_id"`
-	Address      *address     `tfsdk:"address"`
-	Type         types.Int64  `tfsdk:"type"`
+	Id                 types.Int64             `tfsdk:"id"`
+	Name               types.String            `tfsdk:"name"`
+	PhoneNumber        types.String            `tfsdk:"phone_number"`
+	EmailAddress       types.String            `tfsdk:"email_address"`
+	CurrencyId         types.String            `tfsdk:"currency_id"`
+	ParentId           types.Int64             `tfsdk:"parent_id"`
+	BackendId          types.String            `tfsdk:"backend_id"`
+	TimeZone           types.String            `tfsdk:"time_zone"`
+	GLN                types.String            `tfsdk:"gln"`
+	VatNumber          types.String            `tfsdk:"vat_number"`
+	RegistrationNumber types.String            `tfsdk:"registration_number"`
+	Subnet             types.String            `tfsdk:"subnet"`
+	OpeningHours       []openingHours          `tfsdk:"opening_hours"`
+	Exceptions         []openingHoursException `tfsdk:"exceptions"`
+	Address            *address                `tfsdk:"address"`
+	Type               types.Int64             `tfsdk:"type"`
+	Timeouts           timeouts                `tfsdk:"timeouts"`
+}
+
+func (d organizationUnitData) hasOpeningHours() bool {
+	return len(d.OpeningHours) > 0 || len(d.Exceptions) > 0
+}
+
+// duplicateWeekdays returns the days of the week which have opening hours configured more than once.
+func (d organizationUnitData) duplicateWeekdays() []string {
+	var duplicates []string
+
+	count := map[string]int{}
+
+	for _, day := range d.OpeningHours {
+		if day.Day.Null || day.Day.Unknown {
+			continue
+		}
+
+		count[day.Day.Value]++
+
+		if count[day.Day.Value] == 2 {
+			duplicates = append(duplicates, day.Day.Value)
+		}
+	}
+
+	return duplicates
+}
+
+func (d organizationUnitData) getEvaOpeningHours() eva.SetOrganizationUnitOpeningHoursRequest {
+	request := eva.SetOrganizationUnitOpeningHoursRequest{
+		OrganizationUnitID: d.Id.Value,
+		OpeningHours:       []eva.OpeningHours{},
+		Exceptions:         []eva.OpeningHoursException{},
+	}
+
+	for _, day := range d.OpeningHours {
+		var ranges []eva.OpeningHoursRange
+
+		for _, dayRange := range day.Ranges {
+			ranges = append(ranges, eva.OpeningHoursRange{
+				StartTime: dayRange.StartTime.Value,
+				EndTime:   dayRange.EndTime.Value,
+			})
+		}
+
+		for dayOfWeek, weekday := range weekdays {
+			if weekday == day.Day.Value {
+				request.OpeningHours = append(request.OpeningHours, eva.OpeningHours{
+					DayOfWeek: int64(dayOfWeek),
+					Ranges:    ranges,
+				})
+			}
+		}
+	}
+
+	for _, exception := range d.Exceptions {
+		request.Exceptions = append(request.Exceptions, eva.OpeningHoursException{
+			Date:        exception.Date.Value,
+			Description: exception.Description.Value,
+		})
+	}
+
+	return request
+}
+
+// setOpeningHours sets the opening hours and exceptions, these are blocks so they are empty instead of null when not set.
+func (d *organizationUnitData) setOpeningHours(resp *eva.GetOrganizationUnitOpeningHoursResponse) {
+	d.OpeningHours = []openingHours{}
+	d.Exceptions = []openingHoursException{}
+
+	for _, day := range resp.OpeningHours {
+		if day.DayOfWeek < 0 || int(day.DayOfWeek) >= len(weekdays) {
+			continue
+		}
+
+		var ranges []openingHoursRange
+
+		for _, dayRange := range day.Ranges {
+			ranges = append(ranges, openingHoursRange{
+				StartTime: timeOfDayValueOrNull(dayRange.StartTime),
+				EndTime:   timeOfDayValueOrNull(dayRange.EndTime),
+			})
+		}
+
+		d.OpeningHours = append(d.OpeningHours, openingHours{
+			Day:    types.String{Value: weekdays[day.DayOfWeek]},
+			Ranges: ranges,
+		})
+	}
+
+	for _, exception := range resp.Exceptions {
+		d.Exceptions = append(d.Exceptions, openingHoursException{
+			Date:        dateValueOrNull(exception.Date),
+			Description: stringValueOrNull(exception.Description),
+		})
+	}
 }
 
 type organizationUnit struct {
@@ -175,6 +393,9 @@ func (r organizationUnit) Create(ctx context.Context, req tfsdk.CreateResourceRe
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
 	var organizationUnitRequest = eva.CreateOrganizationUnitRequest{
 		Name:                data.Name.Value,
 		PhoneNumber:         data.PhoneNumber.Value,
@@ -184,6 +405,11 @@ func (r organizationUnit) Create(ctx context.Context, req tfsdk.CreateResourceRe
 		CurrencyID:          data.CurrencyId.Value,
 		CostPriceCurrencyID: data.CurrencyId.Value,
 		Type:                data.Type.Value,
+		TimeZone:            data.TimeZone.Value,
+		GLN:                 data.GLN.Value,
+		VatNumber:           data.VatNumber.Value,
+		RegistrationNumber:  data.RegistrationNumber.Value,
+		Subnet:              data.Subnet.Value,
 	}
 	// check if address input is not empty
 	if data.Address != nil {
@@ -211,6 +437,23 @@ func (r organizationUnit) Create(ctx context.Context, req tfsdk.CreateResourceRe
 
 	tflog.Trace(ctx, "Created an organization unit.")
 
+	if data.hasOpeningHours() {
+		// Save the organization unit first, so it is tracked even when setting the opening hours fails.
+		var createdOrganizationUnit = data
+		createdOrganizationUnit.OpeningHours = []openingHours{}
+		createdOrganizationUnit.Exceptions = []openingHoursException{}
+
+		diags = resp.State.Set(ctx, &createdOrganizationUnit)
+		resp.Diagnostics.Append(diags...)
+
+		_, err = r.provider.evaClient.SetOrganizationUnitOpeningHours(ctx, data.getEvaOpeningHours())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Setting opening hours failed.", fmt.Sprintf("Unable to set opening hours of OU, got error: %s", err))
+			return
+		}
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -225,16 +468,25 @@ func (r organizationUnit) Read(ctx context.Context, req tfsdk.ReadResourceReques
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	client_resp, err := r.provider.evaClient.GetOrganizationUnitDetailed(ctx, eva.GetOrganizationUnitDetailedRequest{
 		ID: data.Id.Value,
 	})
 
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The organization unit no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Creating organization unit failed.", fmt.Sprintf("Unable to create example, got error: %s", err))
 		return
 	}
 
-	data.BackendId = types.String{Value: client_resp.BackendID}
+	data.BackendId = stringValueOrNull(client_resp.BackendID)
 	data.CurrencyId = types.String{Value: client_resp.CurrencyID}
 	data.Id = types.Int64{Value: client_resp.ID}
 	data.EmailAddress = types.String{Value: client_resp.EmailAddress}
@@ -242,6 +494,22 @@ func (r organizationUnit) Read(ctx context.Context, req tfsdk.ReadResourceReques
 	data.Name = types.String{Value: client_resp.Name}
 	data.ParentId = types.Int64{Value: client_resp.ParentID}
 	data.Type = types.Int64{Value: client_resp.Type}
+	data.TimeZone = stringValueOrNull(client_resp.TimeZone)
+	data.GLN = stringValueOrNull(client_resp.GLN)
+	data.VatNumber = stringValueOrNull(client_resp.VatNumber)
+	data.RegistrationNumber = stringValueOrNull(client_resp.RegistrationNumber)
+	data.Subnet = stringValueOrNull(client_resp.Subnet)
+
+	openingHoursResp, err := r.provider.evaClient.GetOrganizationUnitOpeningHours(ctx, eva.GetOrganizationUnitOpeningHoursRequest{
+		OrganizationUnitID: data.Id.Value,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Getting opening hours failed.", fmt.Sprintf("Unable to get opening hours of OU, got error: %s", err))
+		return
+	}
+
+	data.setOpeningHours(openingHoursResp)
 
 	if client_resp.Address != nil {
 		data.Address = &address{
@@ -262,21 +530,47 @@ func (r organizationUnit) Read(ctx context.Context, req tfsdk.ReadResourceReques
 
 func (r organizationUnit) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
 	var data organizationUnitData
+	var state organizationUnitData
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
+	// The parent can't be changed by updating the organization unit, it has to be moved instead.
+	if data.ParentId.Value != state.ParentId.Value {
+		_, err := r.provider.evaClient.MoveOrganizationUnit(ctx, eva.MoveOrganizationUnitRequest{
+			ID:       data.Id.Value,
+			ParentID: data.ParentId.Value,
+		})
+
+		if err != nil {
+			resp.Diagnostics.AddError("Moving organization unit failed.", fmt.Sprintf("Unable to move OU to parent %d, got error: %s", data.ParentId.Value, err))
+			return
+		}
+	}
+
 	var organizationUnitRequest = eva.UpdateOrganizationUnitRequest{
 		ID:                  data.Id.Value,
 		Name:                data.Name.Value,
 		PhoneNumber:         data.PhoneNumber.Value,
 		EmailAddress:        data.EmailAddress.Value,
+		BackendID:           data.BackendId.Value,
 		CostPriceCurrencyID: data.CurrencyId.Value,
 		Type:                data.Type.Value,
+		TimeZone:            data.TimeZone.Value,
+		GLN:                 data.GLN.Value,
+		VatNumber:           data.VatNumber.Value,
+		RegistrationNumber:  data.RegistrationNumber.Value,
+		Subnet:              data.Subnet.Value,
 	}
 	// check if address input is not empty
 	if data.Address != nil {
@@ -300,6 +594,16 @@ func (r organizationUnit) Update(ctx context.Context, req tfsdk.UpdateResourceRe
 		return
 	}
 
+	// Opening hours are also set when they are removed from the configuration, to clear them in EVA.
+	if data.hasOpeningHours() || state.hasOpeningHours() {
+		_, err = r.provider.evaClient.SetOrganizationUnitOpeningHours(ctx, data.getEvaOpeningHours())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Setting opening hours failed.", fmt.Sprintf("Unable to set opening hours of OU, got error: %s", err))
+			return
+		}
+	}
+
 	data.Name = types.String{Value: data.Name.Value}
 
 	diags = resp.State.Set(ctx, &data)
@@ -316,6 +620,9 @@ func (r organizationUnit) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.DeleteOrganizationUnit(ctx, eva.DeleteOrganizationUnitRequest{
 		ID: data.Id.Value,
 	})
@@ -328,6 +635,24 @@ func (r organizationUnit) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 	resp.State.RemoveResource(ctx)
 }
 
+// ValidateConfig checks the opening hours of each day of the week are configured once.
+func (r organizationUnit) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data organizationUnitData
+
+	// Unknown opening hours are validated once they are known.
+	if diags := req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("opening_hours"), &data.OpeningHours); diags.HasError() {
+		return
+	}
+
+	for _, day := range data.duplicateWeekdays() {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("opening_hours"),
+			"Duplicate opening hours.",
+			fmt.Sprintf("The opening hours of %s are configured more than once, configure all ranges of a day in a single opening_hours block.", day),
+		)
+	}
+}
+
 func (r organizationUnit) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
 	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
 }
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+type organizationUnitSetType struct{}
+
+func (t organizationUnitSetType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Eva organization unit set configuration. A set is either a static list of organization units, or a filter that dynamically selects organization units.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "ID of the organization unit set.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Name of the organization unit set",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"description": {
+				MarkdownDescription: "Description of the organization unit set",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"organization_unit_ids": {
+				MarkdownDescription: "IDs of the organization units in a static set. Conflicts with `filter`.",
+				Optional:            true,
+				Type: types.SetType{
+					ElemType: types.Int64Type,
+				},
+			},
+			"filter": {
+				MarkdownDescription: "Filter of a dynamic set, organization units matching all of the configured filters are in the set. Conflicts with `organization_unit_ids`.",
+				Optional:            true,
+				Attributes: tfsdk.SingleNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"type": {
+							MarkdownDescription: "Type of the organization units, see the `type` of `eva_organization_unit`",
+							Optional:            true,
+							Type:                types.Int64Type,
+						},
+						"country_id": {
+							MarkdownDescription: "Country ID of the organization units",
+							Optional:            true,
+							Type:                types.StringType,
+						},
+						"parent_id": {
+							MarkdownDescription: "ID of the parent of the organization units",
+							Optional:            true,
+							Type:                types.Int64Type,
+						},
+					},
+				),
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}, nil
+}
+
+func (t organizationUnitSetType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return organizationUnitSet{
+		provider: provider,
+	}, diags
+}
+
+type organizationUnitSetFilterData struct {
+	Type      types.Int64  `tfsdk:"type"`
+	CountryID types.String `tfsdk:"country_id"`
+	ParentID  types.Int64  `tfsdk:"parent_id"`
+}
+
+type organizationUnitSetData struct {
+	ID                  types.Int64                    `tfsdk:"id"`
+	Name                types.String                   `tfsdk:"name"`
+	Description         types.String                   `tfsdk:"description"`
+	OrganizationUnitIDs []int64                        `tfsdk:"organization_unit_ids"`
+	Filter              *organizationUnitSetFilterData `tfsdk:"filter"`
+	Timeouts            timeouts                       `tfsdk:"timeouts"`
+}
+
+type organizationUnitSet struct {
+	provider provider
+}
+
+func (d organizationUnitSetData) getEvaFilter() *eva.OrganizationUnitSetFilter {
+	if d.Filter == nil {
+		return nil
+	}
+
+	return &eva.OrganizationUnitSetFilter{
+		OrganizationUnitType: d.Filter.Type.Value,
+		CountryID:            d.Filter.CountryID.Value,
+		ParentID:             d.Filter.ParentID.Value,
+	}
+}
+
+func (r organizationUnitSet) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	resp.Diagnostics.Append(validateConflictingAttributes(req.Config, true, "organization_unit_ids", "filter")...)
+}
+
+func (r organizationUnitSet) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data organizationUnitSetData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	client_resp, err := r.provider.evaClient.CreateOrganizationUnitSet(ctx, eva.CreateOrganizationUnitSetRequest{
+		Name:                data.Name.Value,
+		Description:         data.Description.Value,
+		OrganizationUnitIDs: data.OrganizationUnitIDs,
+		Filter:              data.getEvaFilter(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Creating organization unit set failed.", fmt.Sprintf("Unable to create organization unit set, got error: %s", err))
+		return
+	}
+
+	data.ID = types.Int64{Value: client_resp.ID}
+
+	tflog.Trace(ctx, "Created an organization unit set.")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r organizationUnitSet) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data organizationUnitSetData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
+	client_resp, err := r.provider.evaClient.GetOrganizationUnitSet(ctx, eva.GetOrganizationUnitSetRequest{
+		ID: data.ID.Value,
+	})
+
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The organization unit set no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Getting organization unit set failed.", fmt.Sprintf("Unable to get organization unit set, got error: %s", err))
+		return
+	}
+
+	data.Name = types.String{Value: client_resp.Name}
+	data.Description = stringValueOrNull(client_resp.Description)
+	data.OrganizationUnitIDs = nil
+	data.Filter = nil
+
+	if len(client_resp.OrganizationUnitIDs) > 0 {
+		data.OrganizationUnitIDs = client_resp.OrganizationUnitIDs
+	}
+
+	if client_resp.Filter != nil {
+		data.Filter = &organizationUnitSetFilterData{
+			Type:      int64ValueOrNull(client_resp.Filter.OrganizationUnitType),
+			CountryID: stringValueOrNull(client_resp.Filter.CountryID),
+			ParentID:  int64ValueOrNull(client_resp.Filter.ParentID),
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r organizationUnitSet) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data organizationUnitSetData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
+	_, err := r.provider.evaClient.UpdateOrganizationUnitSet(ctx, eva.UpdateOrganizationUnitSetRequest{
+		ID:                  data.ID.Value,
+		Name:                data.Name.Value,
+		Description:         data.Description.Value,
+		OrganizationUnitIDs: data.OrganizationUnitIDs,
+		Filter:              data.getEvaFilter(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Updating organization unit set failed.", fmt.Sprintf("Unable to update organization unit set, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r organizationUnitSet) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data organizationUnitSetData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
+	_, err := r.provider.evaClient.DeleteOrganizationUnitSet(ctx, eva.DeleteOrganizationUnitSetRequest{
+		ID: data.ID.Value,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Deleting organization unit set failed.", fmt.Sprintf("Unable to delete organization unit set, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r organizationUnitSet) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestAccEvaOrganizationUnitSetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEvaOrganizationUnitSetResourceConfig("organization_unit_ids = [1]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("eva_organization_unit_set.test", "name", "test"),
+					resource.TestCheckResourceAttr("eva_organization_unit_set.test", "organization_unit_ids.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "eva_organization_unit_set.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccEvaOrganizationUnitSetResourceConfig("filter = { country_id = \"NL\" }"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("eva_organization_unit_set.test", "filter.country_id", "NL"),
+					resource.TestCheckNoResourceAttr("eva_organization_unit_set.test", "organization_unit_ids"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccEvaOrganizationUnitSetResourceConfig(members string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "eva_organization_unit_set" "test" {
+	name = "test"
+	%s
+}`, members)
+}
+
+func TestOrganizationUnitSetValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        organizationUnitSetData
+		expectError bool
+	}{
+		{
+			name:        "static set",
+			data:        testOrganizationUnitSetData([]int64{1, 2}, nil),
+			expectError: false,
+		},
+		{
+			name:        "dynamic set",
+			data:        testOrganizationUnitSetData(nil, &organizationUnitSetFilterData{Type: types.Int64{Null: true}, CountryID: types.String{Value: "NL"}, ParentID: types.Int64{Null: true}}),
+			expectError: false,
+		},
+		{
+			name:        "static and dynamic set",
+			data:        testOrganizationUnitSetData([]int64{1}, &organizationUnitSetFilterData{Type: types.Int64{Null: true}, CountryID: types.String{Value: "NL"}, ParentID: types.Int64{Null: true}}),
+			expectError: true,
+		},
+		{
+			name:        "no members",
+			data:        testOrganizationUnitSetData(nil, nil),
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			schema, _ := organizationUnitSetType{}.GetSchema(ctx)
+			state := tfsdk.State{Schema: schema}
+			state.Set(ctx, &testCase.data)
+
+			resp := tfsdk.ValidateResourceConfigResponse{}
+			organizationUnitSet{}.ValidateConfig(ctx, tfsdk.ValidateResourceConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestOrganizationUnitSetCreateAndRead(t *testing.T) {
+	testCases := []struct {
+		name string
+		data organizationUnitSetData
+	}{
+		{
+			name: "static set",
+			data: testOrganizationUnitSetData([]int64{1, 2}, nil),
+		},
+		{
+			name: "dynamic set",
+			data: testOrganizationUnitSetData(nil, &organizationUnitSetFilterData{Type: types.Int64{Value: 8}, CountryID: types.String{Null: true}, ParentID: types.Int64{Value: 1}}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			server := evatest.NewServer()
+			defer server.Close()
+
+			r := organizationUnitSet{provider: testProvider(server)}
+			schema, _ := organizationUnitSetType{}.GetSchema(ctx)
+
+			plan := tfsdk.Plan{Schema: schema}
+			plan.Set(ctx, &testCase.data)
+
+			createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+			}
+
+			readResp := tfsdk.ReadResourceResponse{State: createResp.State}
+			r.Read(ctx, tfsdk.ReadResourceRequest{State: createResp.State}, &readResp)
+
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+			}
+
+			// Reading the set back shows no difference with the configuration.
+			if !readResp.State.Raw.Equal(createResp.State.Raw) {
+				t.Errorf("expected state %s, got %s", createResp.State.Raw, readResp.State.Raw)
+			}
+		})
+	}
+}
+
+func testOrganizationUnitSetData(organizationUnitIDs []int64, filter *organizationUnitSetFilterData) organizationUnitSetData {
+	return organizationUnitSetData{
+		ID:                  types.Int64{Unknown: true},
+		Name:                types.String{Value: "test"},
+		Description:         types.String{Null: true},
+		OrganizationUnitIDs: organizationUnitIDs,
+		Filter:              filter,
+	}
+}
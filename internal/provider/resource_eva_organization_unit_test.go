@@ -1,41 +1,60 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccEvaOrganizationUnitResource(t *testing.T) {
+	var id string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccEvaOrganizationUnitResourceConfig("shop", "shop@example.com", "1", "EUR"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("scaffolding_example.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("eva_organization_unit.test", "name", "shop"),
+					resource.TestCheckResourceAttr("eva_organization_unit.test", "email_address", "shop@example.com"),
+					testAccCheckResourceID("eva_organization_unit.test", &id, false),
 				),
 			},
-			// ImportState testing
+			// Update and Read testing
 			{
-				ResourceName:      "scaffolding_example.test",
-				ImportState:       true,
-				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute"},
+				Config: testAccEvaOrganizationUnitResourceConfig("renamed shop", "renamed@example.com", "1", "EUR"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("eva_organization_unit.test", "name", "renamed shop"),
+					resource.TestCheckResourceAttr("eva_organization_unit.test", "email_address", "renamed@example.com"),
+					testAccCheckResourceID("eva_organization_unit.test", &id, false),
+				),
 			},
-			// Update and Read testing
+			// Move testing, the shop is moved instead of replaced
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccEvaOrganizationUnitResourceConfig("renamed shop", "renamed@example.com", "eva_organization_unit.parent.id", "EUR"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttrPair("eva_organization_unit.test", "parent_id", "eva_organization_unit.parent", "id"),
+					testAccCheckResourceID("eva_organization_unit.test", &id, false),
+				),
+			},
+			// Replace testing, changing the currency creates a new shop
+			{
+				Config: testAccEvaOrganizationUnitResourceConfig("renamed shop", "renamed@example.com", "eva_organization_unit.parent.id", "USD"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("eva_organization_unit.test", "currency_id", "USD"),
+					testAccCheckResourceID("eva_organization_unit.test", &id, true),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -43,10 +62,320 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
-	return fmt.Sprintf(`
-resource "scaffolding_example" "test" {
-  configurable_attribute = %[1]q
+// testAccCheckResourceID checks whether the ID of the resource changed since the previous check, and keeps track of the ID.
+func testAccCheckResourceID(name string, id *string, changed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		previousID := *id
+		*id = rs.Primary.ID
+
+		if previousID != "" && (previousID != rs.Primary.ID) != changed {
+			return fmt.Errorf("expected ID of %s changed: %t, got %s and %s", name, changed, previousID, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccEvaOrganizationUnitResourceConfig(name string, emailAddress string, parentID string, currencyID string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "eva_organization_unit" "parent" {
+	name        = "parent"
+	parent_id   = 1
+	currency_id = "EUR"
+}
+
+resource "eva_organization_unit" "test" {
+	name          = "%s"
+	parent_id     = %s
+	currency_id   = "%s"
+	email_address = "%s"
+}`, name, parentID, currencyID, emailAddress)
+}
+
+func TestOrganizationUnitPlanRequiresReplace(t *testing.T) {
+	testCases := []struct {
+		name            string
+		parentID        int64
+		currencyID      string
+		requiresReplace bool
+	}{
+		{
+			name:            "parent changed",
+			parentID:        2,
+			currencyID:      "EUR",
+			requiresReplace: false,
+		},
+		{
+			name:            "currency changed",
+			parentID:        1,
+			currencyID:      "USD",
+			requiresReplace: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			prior := testOrganizationUnitData(1, 1, "EUR")
+			proposed := testOrganizationUnitData(1, testCase.parentID, testCase.currencyID)
+
+			resp := testPlanResourceChange(t, "eva_organization_unit", &prior, &proposed)
+
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			currencyPath := tftypes.NewAttributePath().WithAttributeName("currency_id")
+			requiresReplace := false
+
+			for _, path := range resp.RequiresReplace {
+				if path.Equal(currencyPath) {
+					requiresReplace = true
+				} else {
+					t.Errorf("unexpected replace because of %s", path)
+				}
+			}
+
+			if requiresReplace != testCase.requiresReplace {
+				t.Errorf("expected requires replace: %t, got %t", testCase.requiresReplace, requiresReplace)
+			}
+		})
+	}
+}
+
+func TestOrganizationUnitUpdateMovesParent(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := organizationUnit{provider: testProvider(server)}
+	client := &r.provider.evaClient
+
+	created, err := client.CreateOrganizationUnit(ctx, eva.CreateOrganizationUnitRequest{Name: "shop", ParentID: 1, CurrencyID: "EUR"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	schema, _ := organizationUnitType{}.GetSchema(ctx)
+
+	prior := testOrganizationUnitData(created.ID, 1, "EUR")
+	planned := testOrganizationUnitData(created.ID, 2, "EUR")
+
+	state := tfsdk.State{Schema: schema}
+	plan := tfsdk.Plan{Schema: schema}
+
+	state.Set(ctx, &prior)
+	plan.Set(ctx, &planned)
+
+	resp := tfsdk.UpdateResourceResponse{State: state}
+	r.Update(ctx, tfsdk.UpdateResourceRequest{State: state, Plan: plan}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	organizationUnit, err := client.GetOrganizationUnitDetailed(ctx, eva.GetOrganizationUnitDetailedRequest{ID: created.ID})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if organizationUnit.ParentID != 2 {
+		t.Errorf("expected organization unit to be moved to 2, got %d", organizationUnit.ParentID)
+	}
+}
+
+func testOrganizationUnitData(id int64, parentID int64, currencyID string) organizationUnitData {
+	return organizationUnitData{
+		Id:                 types.Int64{Value: id},
+		Name:               types.String{Value: "shop"},
+		PhoneNumber:        types.String{Null: true},
+		EmailAddress:       types.String{Null: true},
+		CurrencyId:         types.String{Value: currencyID},
+		ParentId:           types.Int64{Value: parentID},
+		BackendId:          types.String{Null: true},
+		TimeZone:           types.String{Null: true},
+		GLN:                types.String{Null: true},
+		VatNumber:          types.String{Null: true},
+		RegistrationNumber: types.String{Null: true},
+		Subnet:             types.String{Null: true},
+		OpeningHours:       []openingHours{},
+		Exceptions:         []openingHoursException{},
+		Type:               types.Int64{Null: true},
+		Timeouts:           timeouts{},
+	}
+}
+
+func TestOrganizationUnitValidateConfig(t *testing.T) {
+	monday := openingHours{
+		Day:    types.String{Value: "monday"},
+		Ranges: []openingHoursRange{{StartTime: types.String{Value: "09:00"}, EndTime: types.String{Value: "17:00"}}},
+	}
+	tuesday := openingHours{
+		Day:    types.String{Value: "tuesday"},
+		Ranges: []openingHoursRange{{StartTime: types.String{Value: "09:00"}, EndTime: types.String{Value: "17:00"}}},
+	}
+	mondayEvening := openingHours{
+		Day:    types.String{Value: "monday"},
+		Ranges: []openingHoursRange{{StartTime: types.String{Value: "18:00"}, EndTime: types.String{Value: "21:00"}}},
+	}
+
+	testCases := []struct {
+		name         string
+		openingHours []openingHours
+		expectError  bool
+	}{
+		{
+			name:         "no opening hours",
+			openingHours: []openingHours{},
+			expectError:  false,
+		},
+		{
+			name:         "different days",
+			openingHours: []openingHours{monday, tuesday},
+			expectError:  false,
+		},
+		{
+			name:         "duplicate day",
+			openingHours: []openingHours{monday, tuesday, mondayEvening},
+			expectError:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			schema, _ := organizationUnitType{}.GetSchema(ctx)
+			data := testOrganizationUnitData(1, 1, "EUR")
+			data.OpeningHours = testCase.openingHours
+
+			state := tfsdk.State{Schema: schema}
+			state.Set(ctx, &data)
+
+			resp := tfsdk.ValidateResourceConfigResponse{}
+			organizationUnit{}.ValidateConfig(ctx, tfsdk.ValidateResourceConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
 }
-`, configurableAttribute)
+
+func TestOrganizationUnitOpeningHours(t *testing.T) {
+	data := organizationUnitData{
+		Id: types.Int64{Value: 1},
+		OpeningHours: []openingHours{
+			{
+				Day:    types.String{Value: "monday"},
+				Ranges: []openingHoursRange{{StartTime: types.String{Value: "09:00"}, EndTime: types.String{Value: "17:00"}}},
+			},
+		},
+		Exceptions: []openingHoursException{
+			{Date: types.String{Value: "2022-12-25"}, Description: types.String{Value: "Christmas"}},
+		},
+	}
+
+	request := data.getEvaOpeningHours()
+
+	if len(request.OpeningHours) != 1 || request.OpeningHours[0].DayOfWeek != 1 {
+		t.Fatalf("expected opening hours on monday, got %+v", request.OpeningHours)
+	}
+
+	// EVA returns times with seconds and dates as timestamps.
+	var actual organizationUnitData
+
+	actual.setOpeningHours(&eva.GetOrganizationUnitOpeningHoursResponse{
+		OpeningHours: []eva.OpeningHours{{DayOfWeek: 1, Ranges: []eva.OpeningHoursRange{{StartTime: "09:00:00", EndTime: "17:00:00"}}}},
+		Exceptions:   []eva.OpeningHoursException{{Date: "2022-12-25T00:00:00Z", Description: "Christmas"}},
+	})
+
+	if !reflect.DeepEqual(actual.OpeningHours, data.OpeningHours) || !reflect.DeepEqual(actual.Exceptions, data.Exceptions) {
+		t.Errorf("expected %+v and %+v, got %+v and %+v", data.OpeningHours, data.Exceptions, actual.OpeningHours, actual.Exceptions)
+	}
+
+	// Opening hours and exceptions are blocks, which are empty instead of null.
+	actual.setOpeningHours(&eva.GetOrganizationUnitOpeningHoursResponse{})
+
+	if actual.OpeningHours == nil || actual.Exceptions == nil {
+		t.Errorf("expected empty opening hours and exceptions, got %+v and %+v", actual.OpeningHours, actual.Exceptions)
+	}
+}
+
+func TestOrganizationUnitUpdateClearsFields(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := organizationUnit{provider: testProvider(server)}
+	client := &r.provider.evaClient
+
+	created, err := client.CreateOrganizationUnit(ctx, eva.CreateOrganizationUnitRequest{
+		Name:               "shop",
+		ParentID:           1,
+		CurrencyID:         "EUR",
+		BackendID:          "shop-1",
+		TimeZone:           "Europe/Amsterdam",
+		GLN:                "8712345678906",
+		VatNumber:          "NL123456789B01",
+		RegistrationNumber: "12345678",
+		Subnet:             "10.0.0.0/24",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	schema, _ := organizationUnitType{}.GetSchema(ctx)
+
+	prior := testOrganizationUnitData(created.ID, 1, "EUR")
+	prior.BackendId = types.String{Value: "shop-1"}
+	prior.TimeZone = types.String{Value: "Europe/Amsterdam"}
+	prior.GLN = types.String{Value: "8712345678906"}
+	prior.VatNumber = types.String{Value: "NL123456789B01"}
+	prior.RegistrationNumber = types.String{Value: "12345678"}
+	prior.Subnet = types.String{Value: "10.0.0.0/24"}
+	planned := testOrganizationUnitData(created.ID, 1, "EUR")
+
+	state := tfsdk.State{Schema: schema}
+	plan := tfsdk.Plan{Schema: schema}
+
+	state.Set(ctx, &prior)
+	plan.Set(ctx, &planned)
+
+	updateResp := tfsdk.UpdateResourceResponse{State: state}
+	r.Update(ctx, tfsdk.UpdateResourceRequest{State: state, Plan: plan}, &updateResp)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: updateResp.State}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: updateResp.State}, &readResp)
+
+	var actual organizationUnitData
+
+	readResp.State.Get(ctx, &actual)
+
+	for name, value := range map[string]types.String{
+		"backend_id":          actual.BackendId,
+		"time_zone":           actual.TimeZone,
+		"gln":                 actual.GLN,
+		"vat_number":          actual.VatNumber,
+		"registration_number": actual.RegistrationNumber,
+		"subnet":              actual.Subnet,
+	} {
+		if !value.Null {
+			t.Errorf("expected %s to be cleared, got %v", name, value)
+		}
+	}
 }
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+type primaryOpenIdProviderType struct{}
+
+func (t primaryOpenIdProviderType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Primary OpenID provider of EVA. There is only one primary OpenID provider, so there should only be one of these resources. Destroying it leaves the OpenID provider primary, as EVA always has a primary OpenID provider.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "ID of the primary OpenID provider.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"open_id_provider_id": {
+				MarkdownDescription: "ID of the OpenID provider to make primary, e.g. the `id` of an `eva_open_id_provider`.",
+				Required:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}, nil
+}
+
+func (t primaryOpenIdProviderType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return primaryOpenIdProvider{
+		provider: provider,
+	}, diags
+}
+
+type primaryOpenIdProviderTypeData struct {
+	ID               types.Int64 `tfsdk:"id"`
+	OpenIDProviderID types.Int64 `tfsdk:"open_id_provider_id"`
+	Timeouts         timeouts    `tfsdk:"timeouts"`
+}
+
+type primaryOpenIdProvider struct {
+	provider provider
+}
+
+func (r primaryOpenIdProvider) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data primaryOpenIdProviderTypeData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	_, err := r.provider.evaClient.SetPrimaryOpenIDProvider(ctx, eva.SetPrimaryOpenIDProviderRequest{
+		ID: data.OpenIDProviderID.Value,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Setting primary open Id provider failed.", fmt.Sprintf("Unable to set primary open Id provider, got error: %s", err))
+		return
+	}
+
+	data.ID = data.OpenIDProviderID
+
+	tflog.Trace(ctx, "Set the primary OpenID provider.")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r primaryOpenIdProvider) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data primaryOpenIdProviderTypeData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
+	client_resp, err := r.provider.evaClient.GetOpenIDProvider(ctx, eva.GetOpenIDProviderRequest{
+		ID: data.ID.Value,
+	})
+
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The primary OpenID provider no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Getting openIdProvider data failed.", fmt.Sprintf("Unable to get openIdProvider, got error: %s", err))
+		return
+	}
+
+	// Another OpenID provider was made primary, so it has to be made primary again.
+	if !client_resp.Primary {
+		tflog.Info(ctx, "The OpenID provider is no longer primary, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.OpenIDProviderID = types.Int64{Value: client_resp.ID}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r primaryOpenIdProvider) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan primaryOpenIdProviderTypeData
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the timeouts can be updated, changing the OpenID provider replaces the resource.
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r primaryOpenIdProvider) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	tflog.Info(ctx, "EVA always has a primary OpenID provider, leaving the OpenID provider primary.")
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r primaryOpenIdProvider) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to validate when the primary OpenID provider is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan primaryOpenIdProviderTypeData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.OpenIDProviderID.Unknown {
+		return
+	}
+
+	claimant := fmt.Sprintf("The eva_primary_open_id_provider with OpenID provider %d", plan.OpenIDProviderID.Value)
+
+	if other, ok := r.provider.primaryOpenIdProviders.claim(fmt.Sprint(plan.OpenIDProviderID.Value), claimant); !ok {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("open_id_provider_id"),
+			"Multiple primary OpenID providers.",
+			fmt.Sprintf("Only one OpenID provider can be primary. %s is primary already, setting this OpenID provider as primary as well makes them take the primary role from each other on every apply.", other),
+		)
+	}
+}
+
+func (r primaryOpenIdProvider) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID.", fmt.Sprintf("Expected the ID of the OpenID provider, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("open_id_provider_id"), id)...)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestPrimaryOpenIdProviderWithOpenIdProviders(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	p := testProvider(server)
+	r := openIdProvider{provider: p}
+	primary := primaryOpenIdProvider{provider: p}
+
+	openIdProviderSchema, _ := openIdProviderType{}.GetSchema(ctx)
+	primarySchema, _ := primaryOpenIdProviderType{}.GetSchema(ctx)
+
+	createOpenIdProvider := func(clientID string) tfsdk.State {
+		data := testOpenIdProviderData()
+		data.ID = types.Int64{Null: true}
+		data.ClientID = types.String{Value: clientID}
+
+		config := tfsdk.State{Schema: openIdProviderSchema}
+		config.Set(ctx, &data)
+
+		resp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: openIdProviderSchema}}
+		r.Create(ctx, tfsdk.CreateResourceRequest{Config: tfsdk.Config{Schema: openIdProviderSchema, Raw: config.Raw}}, &resp)
+
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+		}
+
+		return resp.State
+	}
+
+	first := createOpenIdProvider("first")
+	second := createOpenIdProvider("second")
+
+	var firstData, secondData openIdProviderTypeData
+
+	first.Get(ctx, &firstData)
+	second.Get(ctx, &secondData)
+
+	primaryData := primaryOpenIdProviderTypeData{
+		ID:               types.Int64{Unknown: true},
+		OpenIDProviderID: secondData.ID,
+		Timeouts:         timeouts{},
+	}
+
+	primaryPlan := tfsdk.Plan{Schema: primarySchema}
+	primaryPlan.Set(ctx, &primaryData)
+
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: primarySchema}}
+	primary.Create(ctx, tfsdk.CreateResourceRequest{Plan: primaryPlan}, &createResp)
+
+	for _, d := range createResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	// The OpenID provider made primary by the eva_primary_open_id_provider doesn't differ from its configuration.
+	readResp := tfsdk.ReadResourceResponse{State: second}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: second}, &readResp)
+
+	var read openIdProviderTypeData
+
+	readResp.State.Get(ctx, &read)
+
+	if !read.Primary.Null {
+		t.Errorf("expected primary to stay null when it is not configured, got %v", read.Primary)
+	}
+
+	primaryReadResp := tfsdk.ReadResourceResponse{State: createResp.State}
+	primary.Read(ctx, tfsdk.ReadResourceRequest{State: createResp.State}, &primaryReadResp)
+
+	if primaryReadResp.State.Raw.IsNull() {
+		t.Fatalf("expected the primary OpenID provider to be kept in the state")
+	}
+
+	primaryReadResp.State.Get(ctx, &primaryData)
+
+	// Planning the resources together only fails when the other OpenID provider is configured primary as well.
+	for _, firstPrimary := range []bool{false, true} {
+		p.primaryOpenIdProviders = newPrimaryOpenIdProviders()
+		r = openIdProvider{provider: p}
+		primary = primaryOpenIdProvider{provider: p}
+
+		firstData.Primary = types.Bool{Value: firstPrimary}
+
+		diags := testModifyPlan(ctx, r, openIdProviderSchema, read, read)
+		diags.Append(testModifyPlan(ctx, r, openIdProviderSchema, firstData, firstData)...)
+		diags.Append(testModifyPlan(ctx, primary, primarySchema, primaryData, primaryData)...)
+
+		if firstPrimary != diags.HasError() {
+			t.Errorf("expected planning with the first OpenID provider primary: %t to fail: %t, got %v", firstPrimary, firstPrimary, diags)
+		}
+	}
+}
+
+// testModifyPlan plans the resource from the state to the planned data.
+func testModifyPlan(ctx context.Context, r tfsdk.ResourceWithModifyPlan, schema tfsdk.Schema, stateData interface{}, planData interface{}) diag.Diagnostics {
+	state := tfsdk.State{Schema: schema}
+	state.Set(ctx, stateData)
+
+	plan := tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, planData)
+
+	resp := tfsdk.ModifyResourcePlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, tfsdk.ModifyResourcePlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, Plan: plan, State: state}, &resp)
+
+	return resp.Diagnostics
+}
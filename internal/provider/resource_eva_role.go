@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -75,6 +76,14 @@ func (t roleType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics
 					},
 				),
 			},
+			"adopt_existing": {
+				MarkdownDescription: "Adopt an existing role with the same `code` on create, instead of failing to create it. The adopted role is updated to the configured values, including its scoped functionalities.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -97,6 +106,8 @@ type roleProviderTypeData struct {
 	UserType              types.Int64                 `tfsdk:"user_type"`
 	Code                  types.String                `tfsdk:"code"`
 	ScopedFunctionalities []roleFunctionalityTypeData `tfsdk:"scoped_functionalities"`
+	AdoptExisting         types.Bool                  `tfsdk:"adopt_existing"`
+	Timeouts              timeouts                    `tfsdk:"timeouts"`
 }
 
 type roleFunctionalityTypeData struct {
@@ -139,6 +150,34 @@ func (r role) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	if data.AdoptExisting.Value {
+		existing, err := r.findByCode(ctx, data.Code.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Adopting role failed.", fmt.Sprintf("Unable to find role, got error: %s", err))
+			return
+		}
+
+		if existing != nil {
+			data.ID = types.Int64{Value: existing.ID}
+
+			resp.Diagnostics.Append(r.update(ctx, data)...)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			tflog.Info(ctx, "Adopted an existing role.", "id", existing.ID)
+
+			diags = resp.State.Set(ctx, &data)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
 	createdRole, createRoleErr := r.provider.evaClient.CreateRole(ctx, eva.CreateRoleRequest{
 		Name:     data.Name.Value,
 		UserType: data.UserType.Value,
@@ -153,10 +192,12 @@ func (r role) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp
 	data.ID = types.Int64{Value: createdRole.ID}
 
 	diags = resp.State.Set(ctx, &roleProviderTypeData{
-		ID:       types.Int64{Value: data.ID.Value},
-		Name:     types.String{Value: data.Name.Value},
-		UserType: types.Int64{Value: data.UserType.Value},
-		Code:     types.String{Value: data.Code.Value},
+		ID:            types.Int64{Value: data.ID.Value},
+		Name:          types.String{Value: data.Name.Value},
+		UserType:      types.Int64{Value: data.UserType.Value},
+		Code:          types.String{Value: data.Code.Value},
+		AdoptExisting: data.AdoptExisting,
+		Timeouts:      data.Timeouts,
 	})
 
 	tflog.Trace(ctx, "Created a new role.")
@@ -185,10 +226,19 @@ func (r role) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfs
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	roleData, err := r.provider.evaClient.GetRole(ctx, eva.GetRoleRequest{
 		ID: data.ID.Value,
 	})
 
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The role no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Getting role unit failed.", fmt.Sprintf("Unable to get role, got error: %s", err))
 		return
@@ -213,6 +263,24 @@ func (r role) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp
 		return
 	}
 
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.update(ctx, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// update updates the role and replaces its scoped functionalities by the planned ones. The state is left to the
+// caller, so it is only saved once the role and its scoped functionalities are both updated.
+func (r role) update(ctx context.Context, data roleProviderTypeData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	_, err := r.provider.evaClient.UpdateRole(ctx, eva.UpdateRoleRequest{
 		ID:       data.ID.Value,
 		Name:     data.Name.Value,
@@ -221,24 +289,17 @@ func (r role) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Updating role unit failed.", fmt.Sprintf("Unable to update role, got error: %s", err))
-		return
+		diags.AddError("Updating role unit failed.", fmt.Sprintf("Unable to update role, got error: %s", err))
+		return diags
 	}
 
-	diags = resp.State.Set(ctx, &roleProviderTypeData{
-		ID:       types.Int64{Value: data.ID.Value},
-		Name:     types.String{Value: data.Name.Value},
-		UserType: types.Int64{Value: data.UserType.Value},
-		Code:     types.String{Value: data.Code.Value},
-	})
-
 	roleData, getRoleErr := r.provider.evaClient.GetRole(ctx, eva.GetRoleRequest{
 		ID: data.ID.Value,
 	})
 
 	if getRoleErr != nil {
-		resp.Diagnostics.AddError("Getting role permissions data failed.", fmt.Sprintf("Unable to get role, got error: %s", getRoleErr))
-		return
+		diags.AddError("Getting role permissions data failed.", fmt.Sprintf("Unable to get role, got error: %s", getRoleErr))
+		return diags
 	}
 
 	_, detachErr := r.provider.evaClient.DetachFunctionalitiesFromRole(ctx, eva.DetachFunctionalitiesFromRoleRequest{
@@ -247,8 +308,8 @@ func (r role) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp
 	})
 
 	if detachErr != nil {
-		resp.Diagnostics.AddError("Updating role permissions failed.", fmt.Sprintf("Unable to detach current role permissions, got error: %s", detachErr))
-		return
+		diags.AddError("Updating role permissions failed.", fmt.Sprintf("Unable to detach current role permissions, got error: %s", detachErr))
+		return diags
 	}
 
 	_, attachErr := r.provider.evaClient.AttachFunctionalitiesToRole(ctx, eva.AttachFunctionalitiesToRoleRequest{
@@ -257,12 +318,10 @@ func (r role) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp
 	})
 
 	if attachErr != nil {
-		resp.Diagnostics.AddError("Updating role permissions failed.", fmt.Sprintf("Unable to attach new role permissions, got error: %s", detachErr))
-		return
+		diags.AddError("Updating role permissions failed.", fmt.Sprintf("Unable to attach new role permissions, got error: %s", attachErr))
 	}
 
-	diags = resp.State.Set(ctx, &data)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
 
 func (r role) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
@@ -275,6 +334,9 @@ func (r role) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.DeleteRole(ctx, eva.DeleteRoleRequest{
 		ID: data.ID.Value,
 	})
@@ -287,6 +349,48 @@ func (r role) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp
 	resp.State.RemoveResource(ctx)
 }
 
+// ValidateConfig checks a code is configured to adopt existing roles by.
+func (r role) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var adoptExisting types.Bool
+	var code types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("adopt_existing"), &adoptExisting)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("code"), &code)...)
+
+	if adoptExisting.Value && code.Null {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("code"),
+			"Missing code.",
+			"Existing roles are adopted by their code, configure the code of the role to adopt.",
+		)
+	}
+}
+
+// findByCode returns the role with the code, or nil when there is none.
+func (r role) findByCode(ctx context.Context, code string) (*eva.Role, error) {
+	clientResponse, err := r.provider.evaClient.ListRoles(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found *eva.Role
+
+	for i, role := range clientResponse.Result {
+		if role.Code != code {
+			continue
+		}
+
+		if found != nil {
+			return nil, errors.New(fmt.Sprintf("Both role %d and %d have code %q.", found.ID, role.ID, code))
+		}
+
+		found = &clientResponse.Result[i]
+	}
+
+	return found, nil
+}
+
 func (r role) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
 	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
 }
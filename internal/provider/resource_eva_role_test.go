@@ -1,10 +1,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
 )
 
 func TestAccEvaRoleResource(t *testing.T) {
@@ -76,7 +89,7 @@ type roleScopedFunctionalityConfig struct {
 }
 
 func testAccEvaRoleResourceConfig(roleConfig roleConfig, permissionConfig roleScopedFunctionalityConfig) string {
-	return fmt.Sprintf(`
+	return testAccProviderConfig() + fmt.Sprintf(`
 resource "eva_role" "test" {
 	name                   = "%s"
 	user_type              = %d
@@ -90,3 +103,197 @@ resource "eva_role" "test" {
 	]
 }`, roleConfig.name, roleConfig.userType, roleConfig.code, permissionConfig.functionality, permissionConfig.scope, permissionConfig.requires_elevation)
 }
+
+func TestRoleFindByCode(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	for _, req := range []eva.CreateRoleRequest{
+		{Name: "Admin", Code: "admin"},
+		{Name: "Cashier", Code: "cashier"},
+		{Name: "Other cashier", Code: "cashier"},
+	} {
+		if _, err := client.CreateRole(ctx, req); err != nil {
+			t.Fatalf("unable to create role %s: %s", req.Name, err)
+		}
+	}
+
+	r := role{provider: testProvider(server)}
+
+	found, err := r.findByCode(ctx, "admin")
+
+	if err != nil || found == nil || found.Name != "Admin" {
+		t.Errorf("expected to find the admin role, got %+v, %v", found, err)
+	}
+
+	found, err = r.findByCode(ctx, "missing")
+
+	if err != nil || found != nil {
+		t.Errorf("expected no role, got %+v, %v", found, err)
+	}
+
+	if _, err := r.findByCode(ctx, "cashier"); err == nil {
+		t.Errorf("expected an error when roles share their code")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	r = role{provider: provider{evaClient: *eva.NewClient(failing.URL), configured: true}}
+
+	if _, err := r.findByCode(ctx, "admin"); err == nil {
+		t.Errorf("expected an error when the roles can't be listed")
+	}
+}
+
+func TestRoleCreateAdoptExisting(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	existing, err := client.CreateRole(ctx, eva.CreateRoleRequest{Name: "Old name", Code: "adopted"})
+
+	if err != nil {
+		t.Fatalf("unable to create role: %s", err)
+	}
+
+	_, err = client.AttachFunctionalitiesToRole(ctx, eva.AttachFunctionalitiesToRoleRequest{
+		RoleID:                existing.ID,
+		ScopedFunctionalities: []eva.RoleFunctionality{{Functionality: "Old", Scope: 1}},
+	})
+
+	if err != nil {
+		t.Fatalf("unable to attach functionalities: %s", err)
+	}
+
+	r := role{provider: testProvider(server)}
+
+	functionality := roleFunctionalityTypeData{
+		Functionality:     types.String{Value: "New"},
+		Scope:             types.Int64{Value: 2},
+		RequiresElevation: types.Bool{Value: true},
+	}
+
+	// The existing role is adopted and the role with another code is created.
+	for code, expectedID := range map[string]int64{"adopted": existing.ID, "created": 0} {
+		data, diags := testCreateRole(ctx, r, testRoleData(code, functionality))
+
+		for _, d := range diags {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+		}
+
+		if expectedID != 0 && data.ID.Value != expectedID {
+			t.Errorf("expected role %s to be adopted as %d, got %d", code, expectedID, data.ID.Value)
+		}
+
+		if expectedID == 0 && data.ID.Value == existing.ID {
+			t.Errorf("expected role %s to be created, got the adopted role %d", code, data.ID.Value)
+		}
+
+		roleData, err := client.GetRole(ctx, eva.GetRoleRequest{ID: data.ID.Value})
+
+		if err != nil {
+			t.Fatalf("unable to get role %s: %s", code, err)
+		}
+
+		if roleData.Result.Name != "Role" || roleData.Result.Code != code {
+			t.Errorf("expected role %s to be updated, got %+v", code, roleData.Result)
+		}
+
+		expected := []eva.RoleFunctionality{{Functionality: "New", Scope: 2, RequiresElevation: true}}
+
+		if !reflect.DeepEqual(roleData.Result.ScopedFunctionalities, expected) {
+			t.Errorf("expected role %s to have functionalities %+v, got %+v", code, expected, roleData.Result.ScopedFunctionalities)
+		}
+	}
+}
+
+func TestRoleCreateAdoptExistingAttachFailed(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	existing, err := server.NewClient().CreateRole(ctx, eva.CreateRoleRequest{Name: "Role", Code: "adopted"})
+
+	if err != nil {
+		t.Fatalf("unable to create role: %s", err)
+	}
+
+	serverURL, _ := url.Parse(server.URL)
+	proxy := httputil.NewSingleHostReverseProxy(serverURL)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/AttachFunctionalitiesToRole") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		proxy.ServeHTTP(w, r)
+	}))
+	defer failing.Close()
+
+	client := eva.NewClient(failing.URL)
+	client.SetAuthorizationHeader(evatest.Token)
+
+	r := role{provider: provider{evaClient: *client, configured: true}}
+
+	schema, _ := roleType{}.GetSchema(ctx)
+
+	plan := tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, testRoleData("adopted", roleFunctionalityTypeData{
+		Functionality:     types.String{Value: "New"},
+		Scope:             types.Int64{Value: 1},
+		RequiresElevation: types.Bool{Value: false},
+	}))
+
+	resp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected adopting role %d to fail", existing.ID)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state when the functionalities of the adopted role can't be attached, got %s", resp.State.Raw)
+	}
+}
+
+func testCreateRole(ctx context.Context, r role, data roleProviderTypeData) (roleProviderTypeData, diag.Diagnostics) {
+	schema, _ := roleType{}.GetSchema(ctx)
+
+	plan := tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, &data)
+
+	resp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &resp)
+
+	var created roleProviderTypeData
+
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &created)...)
+	}
+
+	return created, resp.Diagnostics
+}
+
+func testRoleData(code string, scopedFunctionalities ...roleFunctionalityTypeData) roleProviderTypeData {
+	return roleProviderTypeData{
+		ID:                    types.Int64{Unknown: true},
+		Name:                  types.String{Value: "Role"},
+		UserType:              types.Int64{Value: 1},
+		Code:                  types.String{Value: code},
+		ScopedFunctionalities: scopedFunctionalities,
+		AdoptExisting:         types.Bool{Value: true},
+		Timeouts:              timeouts{},
+	}
+}
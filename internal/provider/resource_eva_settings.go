@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -22,9 +23,12 @@ func (t settingType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnost
 
 		Attributes: map[string]tfsdk.Attribute{
 			"key": {
-				MarkdownDescription: "Key of the setting.",
+				MarkdownDescription: "Key of the setting. Changing the key forces a new setting to be created.",
 				Required:            true,
 				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
 			},
 			"value": {
 				MarkdownDescription: "Value of the setting",
@@ -32,10 +36,24 @@ func (t settingType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnost
 				Type:                types.StringType,
 			},
 			"organization_unit_id": {
-				MarkdownDescription: "ID of the organization unit to apply the settings for.",
+				MarkdownDescription: "ID of the organization unit to apply the settings for. Conflicts with `organization_unit_set_id`. Changing the organization unit forces a new setting to be created, so the setting is unset for the previous organization unit.",
 				Optional:            true,
 				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
 			},
+			"organization_unit_set_id": {
+				MarkdownDescription: "ID of the organization unit set to apply the settings for. Conflicts with `organization_unit_id`. Changing the organization unit set forces a new setting to be created, so the setting is unset for the previous organization unit set.",
+				Optional:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -49,15 +67,21 @@ func (t settingType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.
 }
 
 type settingTypeData struct {
-	Key                types.String `tfsdk:"key"`
-	Value              types.String `tfsdk:"value"`
-	OrganizationUnitID types.Int64  `tfsdk:"organization_unit_id"`
+	Key                   types.String `tfsdk:"key"`
+	Value                 types.String `tfsdk:"value"`
+	OrganizationUnitID    types.Int64  `tfsdk:"organization_unit_id"`
+	OrganizationUnitSetID types.Int64  `tfsdk:"organization_unit_set_id"`
+	Timeouts              timeouts     `tfsdk:"timeouts"`
 }
 
 type setting struct {
 	provider provider
 }
 
+func (r setting) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	resp.Diagnostics.Append(validateConflictingAttributes(req.Config, false, "organization_unit_id", "organization_unit_set_id")...)
+}
+
 func (r setting) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data settingTypeData
 
@@ -68,11 +92,15 @@ func (r setting) Create(ctx context.Context, req tfsdk.CreateResourceRequest, re
 		return
 	}
 
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
 	// TODO: do we need an ID or some unique identifier for this resource?
 	_, err := r.provider.evaClient.SetSettings(ctx, eva.SetSettingsRequest{
-		Key:                data.Key.Value,
-		Value:              data.Value.Value,
-		OrganizationUnitID: data.OrganizationUnitID.Value,
+		Key:                   data.Key.Value,
+		Value:                 data.Value.Value,
+		OrganizationUnitID:    data.OrganizationUnitID.Value,
+		OrganizationUnitSetID: data.OrganizationUnitSetID.Value,
 	})
 
 	if err != nil {
@@ -96,11 +124,22 @@ func (r setting) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	client_resp, err := r.provider.evaClient.GetSetting(ctx, eva.GetSettingRequest{
-		Key:                data.Key.Value,
-		OrganizationUnitID: data.OrganizationUnitID.Value,
+		Key:                   data.Key.Value,
+		OrganizationUnitID:    data.OrganizationUnitID.Value,
+		OrganizationUnitSetID: data.OrganizationUnitSetID.Value,
 	})
 
+	// The setting, or the organization unit (set) it is set for, no longer exists.
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The setting no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Getting setting data failed.", fmt.Sprintf("Unable to get setting, got error: %s", err))
 		return
@@ -135,11 +174,15 @@ func (r setting) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, re
 		return
 	}
 
+	ctx, cancel := data.Timeouts.update(ctx)
+	defer cancel()
+
 	// TODO: do we need an ID or some unique identifier for this resource?
 	_, err := r.provider.evaClient.SetSettings(ctx, eva.SetSettingsRequest{
-		Key:                data.Key.Value,
-		Value:              data.Value.Value,
-		OrganizationUnitID: data.OrganizationUnitID.Value,
+		Key:                   data.Key.Value,
+		Value:                 data.Value.Value,
+		OrganizationUnitID:    data.OrganizationUnitID.Value,
+		OrganizationUnitSetID: data.OrganizationUnitSetID.Value,
 	})
 
 	if err != nil {
@@ -147,9 +190,6 @@ func (r setting) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, re
 		return
 	}
 
-	data.Value = types.String{Value: data.Value.Value}
-	data.OrganizationUnitID = types.Int64{Value: data.OrganizationUnitID.Value}
-
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -164,9 +204,13 @@ func (r setting) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, re
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
 	_, err := r.provider.evaClient.UnsetSettings(ctx, eva.UnsetSettingsRequest{
-		Key:                data.Key.Value,
-		OrganizationUnitID: data.OrganizationUnitID.Value,
+		Key:                   data.Key.Value,
+		OrganizationUnitID:    data.OrganizationUnitID.Value,
+		OrganizationUnitSetID: data.OrganizationUnitSetID.Value,
 	})
 
 	if err != nil {
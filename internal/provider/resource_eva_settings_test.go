@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestSettingPlanRequiresReplace(t *testing.T) {
+	testCases := []struct {
+		name            string
+		prior           settingTypeData
+		proposed        settingTypeData
+		requiresReplace []string
+	}{
+		{
+			name:            "value changed",
+			prior:           testSettingData("value", types.Int64{Value: 1}, types.Int64{Null: true}),
+			proposed:        testSettingData("other value", types.Int64{Value: 1}, types.Int64{Null: true}),
+			requiresReplace: nil,
+		},
+		{
+			name:            "organization unit changed",
+			prior:           testSettingData("value", types.Int64{Value: 1}, types.Int64{Null: true}),
+			proposed:        testSettingData("value", types.Int64{Value: 2}, types.Int64{Null: true}),
+			requiresReplace: []string{"organization_unit_id"},
+		},
+		{
+			name:            "organization unit changed to organization unit set",
+			prior:           testSettingData("value", types.Int64{Value: 1}, types.Int64{Null: true}),
+			proposed:        testSettingData("value", types.Int64{Null: true}, types.Int64{Value: 1}),
+			requiresReplace: []string{"organization_unit_id", "organization_unit_set_id"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := testPlanResourceChange(t, "eva_setting", &testCase.prior, &testCase.proposed)
+
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			var expected []*tftypes.AttributePath
+
+			for _, name := range testCase.requiresReplace {
+				expected = append(expected, tftypes.NewAttributePath().WithAttributeName(name))
+			}
+
+			if len(resp.RequiresReplace) != len(expected) {
+				t.Fatalf("expected requires replace %v, got %v", expected, resp.RequiresReplace)
+			}
+
+			for _, path := range expected {
+				found := false
+
+				for _, actual := range resp.RequiresReplace {
+					found = found || actual.Equal(path)
+				}
+
+				if !found {
+					t.Errorf("expected requires replace %v, got %v", expected, resp.RequiresReplace)
+				}
+			}
+		})
+	}
+}
+
+func TestSettingUpdateOrganizationUnitSet(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := setting{provider: testProvider(server)}
+	schema, _ := settingType{}.GetSchema(ctx)
+
+	prior := testSettingData("value", types.Int64{Null: true}, types.Int64{Value: 1})
+	planned := testSettingData("other value", types.Int64{Null: true}, types.Int64{Value: 1})
+
+	state := tfsdk.State{Schema: schema}
+	plan := tfsdk.Plan{Schema: schema}
+
+	state.Set(ctx, &prior)
+	plan.Set(ctx, &planned)
+
+	updateResp := tfsdk.UpdateResourceResponse{State: state}
+	r.Update(ctx, tfsdk.UpdateResourceRequest{State: state, Plan: plan}, &updateResp)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	// The state has to match the plan, otherwise Terraform reports an inconsistent result after apply.
+	if !updateResp.State.Raw.Equal(plan.Raw) {
+		t.Errorf("expected state %s, got %s", plan.Raw, updateResp.State.Raw)
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: updateResp.State}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: updateResp.State}, &readResp)
+
+	var actual settingTypeData
+
+	readResp.State.Get(ctx, &actual)
+
+	if actual.Value.Value != "other value" {
+		t.Errorf("expected the value of the organization unit set to be updated, got %v", actual.Value)
+	}
+}
+
+func TestSettingReadRemovesResource(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	r := setting{provider: testProvider(server)}
+	schema, _ := settingType{}.GetSchema(ctx)
+
+	testCases := []struct {
+		name string
+		data settingTypeData
+	}{
+		{
+			name: "setting unset",
+			data: testSettingData("value", types.Int64{Null: true}, types.Int64{Value: 1}),
+		},
+		{
+			name: "organization unit not found",
+			data: testSettingData("value", types.Int64{Value: 404}, types.Int64{Null: true}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schema}
+			state.Set(ctx, &testCase.data)
+
+			readResp := tfsdk.ReadResourceResponse{State: state}
+			r.Read(ctx, tfsdk.ReadResourceRequest{State: state}, &readResp)
+
+			for _, d := range readResp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+			}
+
+			if !readResp.State.Raw.IsNull() {
+				t.Errorf("expected the setting to be removed from the state, got %s", readResp.State.Raw)
+			}
+		})
+	}
+}
+
+func testSettingData(value string, organizationUnitID types.Int64, organizationUnitSetID types.Int64) settingTypeData {
+	return settingTypeData{
+		Key:                   types.String{Value: "Setting"},
+		Value:                 types.String{Value: value},
+		OrganizationUnitID:    organizationUnitID,
+		OrganizationUnitSetID: organizationUnitSetID,
+		Timeouts:              timeouts{},
+	}
+}
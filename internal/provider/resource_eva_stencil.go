@@ -2,21 +2,32 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pmezard/go-difflib/difflib"
+
 	"github.com/mad-it/terraform-provider-eva/internal/eva"
 )
 
 type stencilType struct{}
 
 func (t stencilType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+	schema := tfsdk.Schema{
 		MarkdownDescription: "Eva stencil configuration.",
+		// Version 1 accepts names for the type, paper format and paper orientation.
+		Version: 1,
 
 		Attributes: map[string]tfsdk.Attribute{
 			"id": {
@@ -33,7 +44,12 @@ func (t stencilType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnost
 				Type:                types.StringType,
 			},
 			"organization_unit_id": {
-				MarkdownDescription: "Organization that stencil belongs to",
+				MarkdownDescription: "Organization that stencil belongs to. Conflicts with `organization_unit_set_id`.",
+				Optional:            true,
+				Type:                types.Int64Type,
+			},
+			"organization_unit_set_id": {
+				MarkdownDescription: "Organization unit set that stencil belongs to. Conflicts with `organization_unit_id`.",
 				Optional:            true,
 				Type:                types.Int64Type,
 			},
@@ -48,49 +64,59 @@ func (t stencilType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnost
 				Type:                types.StringType,
 			},
 			"header": {
-				MarkdownDescription: "Header of the stencil template",
+				MarkdownDescription: "Header of the stencil template. Conflicts with `header_file`.",
 				Optional:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					handlebarsValidator{},
+				},
 			},
 			"template": {
-				MarkdownDescription: "Template of the stencil",
+				MarkdownDescription: "Template of the stencil. Conflicts with `template_file`.",
 				Optional:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					handlebarsValidator{},
+				},
 			},
 			"footer": {
-				MarkdownDescription: "Footer of the stencil",
+				MarkdownDescription: "Footer of the stencil. Conflicts with `footer_file`.",
 				Optional:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					handlebarsValidator{},
+				},
 			},
 			"helpers": {
-				MarkdownDescription: "Helper script for the stencil template",
+				MarkdownDescription: "Helper script for the stencil template. Conflicts with `helpers_file`.",
 				Optional:            true,
 				Type:                types.StringType,
 			},
 			"type": {
-				MarkdownDescription: `Type of the stencil:
-				- Template = 1,
-				- Partial = 2,
-				- Layout = 3
-				`,
-				Optional: true,
-				Type:     types.Int64Type,
+				MarkdownDescription: "Type of the stencil, one of " + stencilTypes.markdownDescription() + ".",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					enumValidator{Enum: stencilTypes},
+				},
 			},
 			"layout": {
-				MarkdownDescription: "Type of the stencil",
+				MarkdownDescription: "Name or ID of the layout stencil, a stencil with type `layout`.",
 				Optional:            true,
 				Type:                types.StringType,
 			},
 			"destination": {
-				MarkdownDescription: `Destination of the stencil:
-				- Mail = 1,
-				- Sms = 2,
-				- Pdf = 4,
-				- Thermal = 8,
-				- Notification = 16
-				`,
-				Optional: true,
-				Type:     types.Int64Type,
+				MarkdownDescription: "Destinations of the stencil as the sum of the numbers of the destinations in `destinations`. Conflicts with `destinations`.",
+				Optional:            true,
+				Type:                types.Int64Type,
+				DeprecationMessage:  "Use destinations instead.",
+			},
+			"destinations": {
+				MarkdownDescription: "Destinations of the stencil, any of " + stencilDestinations.markdownDescription() + ". Conflicts with `destination`.",
+				Optional:            true,
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
 			},
 			"paper_properties": {
 				MarkdownDescription: "Paper's properties block configuration",
@@ -108,30 +134,20 @@ func (t stencilType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnost
 							Type:                types.BoolType,
 						},
 						"format": {
-							MarkdownDescription: `Paper format:
-							- A3 = 1,
-							- A4 = 2,
-							- A5 = 3,
-							- Legal = 4,
-							- Letter = 5,
-							- Tabloid = 6,
-							- Auto = 7,
-							- Ledger = 8,
-							- A0 = 9,
-							- A1 = 10,
-							- A2 = 11,
-							- A6 = 12
-							`,
-							Optional: true,
-							Type:     types.Int64Type,
+							MarkdownDescription: "Paper format, one of " + paperFormats.markdownDescription() + ".",
+							Optional:            true,
+							Type:                types.StringType,
+							Validators: []tfsdk.AttributeValidator{
+								enumValidator{Enum: paperFormats},
+							},
 						},
 						"orientation": {
-							MarkdownDescription: `Paper orientation:
-							- Portrait = 1,
-							- Landscape = 2
-							`,
-							Optional: true,
-							Type:     types.Int64Type,
+							MarkdownDescription: "Paper orientation, one of " + paperOrientations.markdownDescription() + ".",
+							Optional:            true,
+							Type:                types.StringType,
+							Validators: []tfsdk.AttributeValidator{
+								enumValidator{Enum: paperOrientations},
+							},
 						},
 						"thermal_printer_template_type": {
 							MarkdownDescription: "Paper orientation",
@@ -192,10 +208,95 @@ func (t stencilType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnost
 					},
 				),
 			},
+			"variants": stencilVariantsAttribute(),
+			"referenced_partials": {
+				MarkdownDescription: "Names of the partials referenced by the templates of the stencil and its variants.",
+				Computed:            true,
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
-	}, nil
+	}
+
+	for _, name := range stencilContentNames {
+		schema.Attributes[name+"_file"] = tfsdk.Attribute{
+			MarkdownDescription: fmt.Sprintf("Path of a file with the %s of the stencil, only a hash of the contents is stored in the state. Conflicts with `%s`.", name, name),
+			Optional:            true,
+			Type:                types.StringType,
+		}
+		schema.Attributes[name+"_file_sha256"] = tfsdk.Attribute{
+			MarkdownDescription: fmt.Sprintf("SHA-256 of the contents of `%s_file`.", name),
+			Computed:            true,
+			Type:                types.StringType,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				fileHashModifier{File: name + "_file"},
+			},
+		}
+	}
+
+	return schema, nil
 }
 
+var (
+	stencilTypes = enum{
+		"template": 1,
+		"partial":  2,
+		"layout":   3,
+	}
+	stencilDestinations = enum{
+		"mail":         1,
+		"sms":          2,
+		"pdf":          4,
+		"thermal":      8,
+		"notification": 16,
+	}
+	paperFormats = enum{
+		"A3":      1,
+		"A4":      2,
+		"A5":      3,
+		"Legal":   4,
+		"Letter":  5,
+		"Tabloid": 6,
+		"Auto":    7,
+		"Ledger":  8,
+		"A0":      9,
+		"A1":      10,
+		"A2":      11,
+		"A6":      12,
+	}
+	paperOrientations = enum{
+		"portrait":  1,
+		"landscape": 2,
+	}
+)
+
+// upgradeStencilStateV0 upgrades the state from before the type, paper format and paper orientation accepted names.
+// The numbers are kept as they are, so configurations with numbers have no changes.
+func upgradeStencilStateV0(state map[string]interface{}) error {
+	stringify := func(attributes map[string]interface{}, name string) {
+		if number, ok := attributes[name].(json.Number); ok {
+			attributes[name] = number.String()
+		}
+	}
+
+	stringify(state, "type")
+	state["destinations"] = nil
+
+	if paperProperties, ok := state["paper_properties"].(map[string]interface{}); ok {
+		stringify(paperProperties, "format")
+		stringify(paperProperties, "orientation")
+	}
+
+	return nil
+}
+
+// stencilContentNames are the names of the contents of a stencil, which can be configured inline or read from a file.
+var stencilContentNames = []string{"header", "template", "footer", "helpers"}
+
 func (t stencilType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
 	provider, diags := convertProviderType(in)
 
@@ -205,19 +306,32 @@ func (t stencilType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.
 }
 
 type stencilTypeData struct {
-	ID                 types.Int64              `tfsdk:"id"`
-	Name               types.String             `tfsdk:"name"`
-	OrganizationUnitID types.Int64              `tfsdk:"organization_unit_id"`
-	LanguageID         types.String             `tfsdk:"language_id"`
-	CountryID          types.String             `tfsdk:"country_id"`
-	Header             types.String             `tfsdk:"header"`
-	Template           types.String             `tfsdk:"template"`
-	Footer             types.String             `tfsdk:"footer"`
-	Helpers            types.String             `tfsdk:"helpers"`
-	Type               types.Int64              `tfsdk:"type"`
-	Layout             types.String             `tfsdk:"layout"`
-	Destination        types.Int64              `tfsdk:"destination"`
-	PaperProperties    *paperPropertiesTypeData `tfsdk:"paper_properties"`
+	ID                    types.Int64              `tfsdk:"id"`
+	Name                  types.String             `tfsdk:"name"`
+	OrganizationUnitID    types.Int64              `tfsdk:"organization_unit_id"`
+	OrganizationUnitSetID types.Int64              `tfsdk:"organization_unit_set_id"`
+	LanguageID            types.String             `tfsdk:"language_id"`
+	CountryID             types.String             `tfsdk:"country_id"`
+	Header                types.String             `tfsdk:"header"`
+	Template              types.String             `tfsdk:"template"`
+	Footer                types.String             `tfsdk:"footer"`
+	Helpers               types.String             `tfsdk:"helpers"`
+	HeaderFile            types.String             `tfsdk:"header_file"`
+	HeaderFileSHA256      types.String             `tfsdk:"header_file_sha256"`
+	TemplateFile          types.String             `tfsdk:"template_file"`
+	TemplateFileSHA256    types.String             `tfsdk:"template_file_sha256"`
+	FooterFile            types.String             `tfsdk:"footer_file"`
+	FooterFileSHA256      types.String             `tfsdk:"footer_file_sha256"`
+	HelpersFile           types.String             `tfsdk:"helpers_file"`
+	HelpersFileSHA256     types.String             `tfsdk:"helpers_file_sha256"`
+	Type                  types.String             `tfsdk:"type"`
+	Layout                types.String             `tfsdk:"layout"`
+	Destination           types.Int64              `tfsdk:"destination"`
+	Destinations          []string                 `tfsdk:"destinations"`
+	PaperProperties       *paperPropertiesTypeData `tfsdk:"paper_properties"`
+	Variants              []stencilVariantTypeData `tfsdk:"variants"`
+	ReferencedPartials    types.List               `tfsdk:"referenced_partials"`
+	Timeouts              timeouts                 `tfsdk:"timeouts"`
 }
 
 type paperMarginTypeData struct {
@@ -237,57 +351,715 @@ type paperPropertiesTypeData struct {
 	WaitForNetworkIdle         types.Bool           `tfsdk:"wait_for_network_idle"`
 	WaitForJS                  types.Bool           `tfsdk:"wait_for_js"`
 	Size                       *paperSizeTypeData   `tfsdk:"size"`
-	Format                     types.Int64          `tfsdk:"format"`
-	Orientation                types.Int64          `tfsdk:"orientation"`
+	Format                     types.String         `tfsdk:"format"`
+	Orientation                types.String         `tfsdk:"orientation"`
 	Margin                     *paperMarginTypeData `tfsdk:"margin"`
 	ThermalPrinterTemplateType types.Int64          `tfsdk:"thermal_printer_template_type"`
 }
 
+// stencilContent links a content of the stencil to its inline value, its file and the hash of that file.
+type stencilContent struct {
+	Name   string
+	Inline *types.String
+	File   *types.String
+	Hash   *types.String
+}
+
+func (d *stencilTypeData) getStencilContents() []stencilContent {
+	return []stencilContent{
+		{Name: "header", Inline: &d.Header, File: &d.HeaderFile, Hash: &d.HeaderFileSHA256},
+		{Name: "template", Inline: &d.Template, File: &d.TemplateFile, Hash: &d.TemplateFileSHA256},
+		{Name: "footer", Inline: &d.Footer, File: &d.FooterFile, Hash: &d.FooterFileSHA256},
+		{Name: "helpers", Inline: &d.Helpers, File: &d.HelpersFile, Hash: &d.HelpersFileSHA256},
+	}
+}
+
+// readContents returns the contents of the stencil by name, read from the files when these are configured.
+// The hashes of the files are updated to the contents that were read, planned hashes have to match these.
+func (d *stencilTypeData) readContents() (map[string]string, error) {
+	contents := map[string]string{}
+
+	for _, content := range d.getStencilContents() {
+		if content.File.Null {
+			contents[content.Name] = content.Inline.Value
+			*content.Hash = types.String{Null: true}
+			continue
+		}
+
+		file, err := readStencilFile(*content.File, *content.Hash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		contents[content.Name] = file
+		*content.Hash = types.String{Value: sha256Hex(file)}
+	}
+
+	return contents, nil
+}
+
+// readStencilFile reads the contents of a file of the stencil or one of its variants. When the hash of the file was
+// planned, the contents have to match it, otherwise the file changed since the plan and contents which were not
+// planned would be uploaded to EVA.
+func readStencilFile(file types.String, plannedHash types.String) (string, error) {
+	contents, err := os.ReadFile(file.Value)
+
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("Unable to read %s, got error: %s", file.Value, err))
+	}
+
+	if !plannedHash.Null && !plannedHash.Unknown && plannedHash.Value != sha256Hex(string(contents)) {
+		return "", errors.New(fmt.Sprintf("The contents of %s changed since the plan, run terraform plan again to review the changes.", file.Value))
+	}
+
+	return string(contents), nil
+}
+
+// setContents sets the contents of the stencil as returned by EVA. Contents read from a file are only stored as a hash.
+func (d *stencilTypeData) setContents(contents map[string]string) {
+	for _, content := range d.getStencilContents() {
+		if content.File.Null {
+			*content.Inline = types.String{Value: contents[content.Name]}
+			*content.Hash = types.String{Null: true}
+		} else {
+			*content.Inline = types.String{Null: true}
+			*content.Hash = types.String{Value: sha256Hex(contents[content.Name])}
+		}
+	}
+}
+
+func getEvaStencilContents(template *eva.GetMessageTemplateByIDResponse) map[string]string {
+	return map[string]string{
+		"header":   template.Header,
+		"template": template.Template,
+		"footer":   template.Footer,
+		"helpers":  template.Helpers,
+	}
+}
+
+func sha256Hex(value string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(value)))
+}
+
+func (d stencilTypeData) getEvaPaperProperties() *eva.PaperProperties {
+	if d.PaperProperties == nil {
+		return nil
+	}
+
+	properties := &eva.PaperProperties{
+		WaitForNetworkIdle:         d.PaperProperties.WaitForNetworkIdle.Value,
+		WaitForJS:                  d.PaperProperties.WaitForJS.Value,
+		Format:                     paperFormats.valuePointer(d.PaperProperties.Format),
+		Orientation:                paperOrientations.valuePointer(d.PaperProperties.Orientation),
+		ThermalPrinterTemplateType: int64Pointer(d.PaperProperties.ThermalPrinterTemplateType),
+	}
+
+	if d.PaperProperties.Size != nil {
+		properties.Size = &eva.PaperSize{
+			Width:             d.PaperProperties.Size.Width.Value,
+			Height:            d.PaperProperties.Size.Height.Value,
+			DeviceScaleFactor: float64Pointer(d.PaperProperties.Size.DeviceScaleFactor),
+		}
+	}
+
+	if d.PaperProperties.Margin != nil {
+		properties.Margin = &eva.PaperMargin{
+			Top:    int64Pointer(d.PaperProperties.Margin.Top),
+			Left:   int64Pointer(d.PaperProperties.Margin.Left),
+			Bottom: int64Pointer(d.PaperProperties.Margin.Bottom),
+			Right:  int64Pointer(d.PaperProperties.Margin.Right),
+		}
+	}
+
+	return properties
+}
+
+// getPaperPropertiesData returns the paper properties as returned by EVA. EVA returns zero values for properties
+// that were never set, so these are only kept when the current paper properties have them.
+func getPaperPropertiesData(current *paperPropertiesTypeData, properties *eva.PaperProperties) *paperPropertiesTypeData {
+	if properties == nil || (current == nil && isEmptyPaperProperties(properties)) {
+		return nil
+	}
+
+	if current == nil {
+		current = &paperPropertiesTypeData{
+			Format:                     types.String{Null: true},
+			Orientation:                types.String{Null: true},
+			ThermalPrinterTemplateType: types.Int64{Null: true},
+		}
+	}
+
+	data := &paperPropertiesTypeData{
+		WaitForNetworkIdle:         types.Bool{Value: properties.WaitForNetworkIdle},
+		WaitForJS:                  types.Bool{Value: properties.WaitForJS},
+		Format:                     paperFormats.pointerValueOrNull(current.Format, properties.Format),
+		Orientation:                paperOrientations.pointerValueOrNull(current.Orientation, properties.Orientation),
+		ThermalPrinterTemplateType: int64PointerValueOrNull(current.ThermalPrinterTemplateType, properties.ThermalPrinterTemplateType),
+	}
+
+	if properties.Size != nil {
+		deviceScaleFactor := types.Float64{Null: true}
+
+		if current.Size != nil {
+			deviceScaleFactor = current.Size.DeviceScaleFactor
+		}
+
+		data.Size = &paperSizeTypeData{
+			Width:             types.String{Value: properties.Size.Width},
+			Height:            types.String{Value: properties.Size.Height},
+			DeviceScaleFactor: float64PointerValueOrNull(deviceScaleFactor, properties.Size.DeviceScaleFactor),
+		}
+	}
+
+	if properties.Margin != nil && (current.Margin != nil || !isEmptyPaperMargin(properties.Margin)) {
+		margin := current.Margin
+
+		if margin == nil {
+			margin = &paperMarginTypeData{
+				Bottom: types.Int64{Null: true},
+				Right:  types.Int64{Null: true},
+			}
+		}
+
+		data.Margin = &paperMarginTypeData{
+			Top:    types.Int64{Value: int64OrZero(properties.Margin.Top)},
+			Left:   types.Int64{Value: int64OrZero(properties.Margin.Left)},
+			Bottom: int64PointerValueOrNull(margin.Bottom, properties.Margin.Bottom),
+			Right:  int64PointerValueOrNull(margin.Right, properties.Margin.Right),
+		}
+	}
+
+	return data
+}
+
+func isEmptyPaperProperties(properties *eva.PaperProperties) bool {
+	return !properties.WaitForNetworkIdle &&
+		!properties.WaitForJS &&
+		int64OrZero(properties.Format) == 0 &&
+		int64OrZero(properties.Orientation) == 0 &&
+		int64OrZero(properties.ThermalPrinterTemplateType) == 0 &&
+		(properties.Size == nil || (properties.Size.Width == "" && properties.Size.Height == "")) &&
+		(properties.Margin == nil || isEmptyPaperMargin(properties.Margin))
+}
+
+func isEmptyPaperMargin(margin *eva.PaperMargin) bool {
+	return int64OrZero(margin.Top) == 0 &&
+		int64OrZero(margin.Left) == 0 &&
+		int64OrZero(margin.Bottom) == 0 &&
+		int64OrZero(margin.Right) == 0
+}
+
+// getEvaDestination returns the destinations of the stencil as the flags EVA expects.
+func (d stencilTypeData) getEvaDestination() int64 {
+	if d.Destinations != nil {
+		return stencilDestinations.flagsValue(d.Destinations)
+	}
+
+	return d.Destination.Value
+}
+
+func (d stencilTypeData) getEvaCreateMessageTemplateRequest(contents map[string]string) eva.CreateMessageTemplateRequest {
+	return eva.CreateMessageTemplateRequest{
+		Name:                  d.Name.Value,
+		OrganizationUnitID:    d.OrganizationUnitID.Value,
+		OrganizationUnitSetID: d.OrganizationUnitSetID.Value,
+		LanguageID:            d.LanguageID.Value,
+		CountryID:             d.CountryID.Value,
+		Header:                contents["header"],
+		Template:              contents["template"],
+		Footer:                contents["footer"],
+		Helpers:               contents["helpers"],
+		Type:                  stencilTypes.valueOf(d.Type),
+		Layout:                d.Layout.Value,
+		Destination:           d.getEvaDestination(),
+		PaperProperties:       d.getEvaPaperProperties(),
+	}
+}
+
+func (d stencilTypeData) getEvaUpdateMessageTemplateRequest(contents map[string]string) eva.UpdateMessageTemplateRequest {
+	return eva.UpdateMessageTemplateRequest{
+		ID:                    d.ID.Value,
+		Name:                  d.Name.Value,
+		OrganizationUnitID:    d.OrganizationUnitID.Value,
+		OrganizationUnitSetID: d.OrganizationUnitSetID.Value,
+		LanguageID:            d.LanguageID.Value,
+		CountryID:             d.CountryID.Value,
+		Header:                contents["header"],
+		Template:              contents["template"],
+		Footer:                contents["footer"],
+		Helpers:               contents["helpers"],
+		Layout:                d.Layout.Value,
+		Destination:           d.getEvaDestination(),
+		PaperProperties:       d.getEvaPaperProperties(),
+	}
+}
+
+// handlebarsTemplate is a Handlebars template of the stencil or one of its variants.
+type handlebarsTemplate struct {
+	Path   *tftypes.AttributePath
+	Source string
+	// File is set when the template is read from a file, the attribute validators only validate inline templates.
+	File bool
+}
+
+// getHandlebarsTemplates returns the known Handlebars templates of the stencil and its variants. The helpers are
+// JavaScript, so these are left out. Files that can't be read are left out too, the plan modifiers of their hashes
+// already report these.
+func (d stencilTypeData) getHandlebarsTemplates() []handlebarsTemplate {
+	var templates []handlebarsTemplate
+
+	appendTemplate := func(path *tftypes.AttributePath, name string, inline types.String, file types.String) {
+		if !file.Null && !file.Unknown {
+			if contents, err := os.ReadFile(file.Value); err == nil {
+				templates = append(templates, handlebarsTemplate{Path: path.WithAttributeName(name + "_file"), Source: string(contents), File: true})
+			}
+		} else if !inline.Null && !inline.Unknown {
+			templates = append(templates, handlebarsTemplate{Path: path.WithAttributeName(name), Source: inline.Value})
+		}
+	}
+
+	for _, content := range d.getStencilContents() {
+		if content.Name != "helpers" {
+			appendTemplate(tftypes.NewAttributePath(), content.Name, *content.Inline, *content.File)
+		}
+	}
+
+	for i, variant := range d.Variants {
+		appendTemplate(stencilVariantsPath.WithElementKeyInt(i), "template", variant.Template, variant.TemplateFile)
+	}
+
+	return templates
+}
+
+// hasUnknownTemplates returns whether any of the Handlebars templates of the stencil or its variants is not known yet.
+func (d stencilTypeData) hasUnknownTemplates() bool {
+	for _, content := range d.getStencilContents() {
+		if content.Inline.Unknown || content.File.Unknown {
+			return true
+		}
+	}
+
+	for _, variant := range d.Variants {
+		if variant.Template.Unknown || variant.TemplateFile.Unknown {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getReferencedPartials returns the sorted names of the partials referenced by the templates.
+func getReferencedPartials(sources []string) types.List {
+	partials := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+
+	for _, name := range referencedPartials(sources) {
+		partials.Elems = append(partials.Elems, types.String{Value: name})
+	}
+
+	return partials
+}
+
+// referencedPartials returns the sorted names of the partials referenced by the templates.
+// Templates that can't be parsed are left out, these are reported by the validation of the templates.
+func referencedPartials(sources []string) []string {
+	names := map[string]bool{}
+
+	for _, source := range sources {
+		partials, err := parseHandlebars(source)
+
+		if err != nil {
+			continue
+		}
+
+		for _, partial := range partials {
+			names[partial] = true
+		}
+	}
+
+	var sortedNames []string
+
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+
+	sort.Strings(sortedNames)
+
+	return sortedNames
+}
+
+// getPlannedReferencedPartials returns the partials referenced by the templates of the stencil and its variants,
+// unknown when any of the templates is not known yet.
+func (d stencilTypeData) getPlannedReferencedPartials() types.List {
+	if d.hasUnknownTemplates() {
+		return types.List{ElemType: types.StringType, Unknown: true}
+	}
+
+	var sources []string
+
+	for _, template := range d.getHandlebarsTemplates() {
+		sources = append(sources, template.Source)
+	}
+
+	return getReferencedPartials(sources)
+}
+
+// validateTemplates validates the syntax of the templates read from files, and checks whether the partials
+// and layout the stencil refers to exist in EVA. The parser doesn't support all syntax of EVA, so templates which
+// can't be parsed are only a warning.
+//
+// Other eva_stencil resources can't be used for this check: Terraform only plans the resources a stencil depends on
+// before the stencil, the order of other resources is not known. So partials and layouts which are created in the
+// same apply are reported as well, as these are only created first when the stencil depends on them.
+func (s stencil) validateTemplates(ctx context.Context, data stencilTypeData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	partialPaths := map[string]*tftypes.AttributePath{}
+
+	for _, template := range data.getHandlebarsTemplates() {
+		partials, err := parseHandlebars(template.Source)
+
+		if err != nil {
+			if template.File {
+				diags.AddAttributeWarning(template.Path, "Unable to validate template file.", handlebarsParseWarning(err))
+			}
+
+			continue
+		}
+
+		for _, partial := range partials {
+			if _, ok := partialPaths[partial]; !ok {
+				partialPaths[partial] = template.Path
+			}
+		}
+	}
+
+	hasLayout := !data.Layout.Null && !data.Layout.Unknown && data.Layout.Value != ""
+
+	if len(partialPaths) == 0 && !hasLayout {
+		return diags
+	}
+
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
+	clientResponse, err := s.provider.evaClient.ListMessageTemplates(ctx, eva.ListMessageTemplatesRequest{})
+
+	if err != nil {
+		tflog.Info(ctx, "Unable to list the stencils to check the partials and layout.", "error", err)
+		return diags
+	}
+
+	existing := map[int64]map[string]bool{
+		stencilTypes["partial"]: {},
+		stencilTypes["layout"]:  {},
+	}
+
+	for _, template := range clientResponse.Result {
+		if names, ok := existing[template.Type]; ok {
+			names[template.Name] = true
+			names[fmt.Sprint(template.ID)] = true
+		}
+	}
+
+	for partial, path := range partialPaths {
+		if !existing[stencilTypes["partial"]][partial] {
+			diags.AddAttributeWarning(path, "Partial not found.", fmt.Sprintf("There is no partial stencil named %q in EVA. When it is created by an eva_stencil resource in the same apply, add that resource to the depends_on of this stencil, so the partial is created first.", partial))
+		}
+	}
+
+	if hasLayout && !existing[stencilTypes["layout"]][data.Layout.Value] {
+		diags.AddAttributeWarning(tftypes.NewAttributePath().WithAttributeName("layout"), "Layout not found.", fmt.Sprintf("There is no layout stencil named %q in EVA. When it is created by an eva_stencil resource in the same apply, refer to its name or add that resource to the depends_on of this stencil, so the layout is created first.", data.Layout.Value))
+	}
+
+	return diags
+}
+
+// getReferencingStencils returns the sorted names of the other stencils in EVA which refer to the partial or layout
+// by its name, or by its ID as well when byID is set. EVA doesn't list the templates of the stencils, so each stencil
+// is read, through the cache of the client so the stencils are read once for all partials and layouts in a plan.
+func (s stencil) getReferencingStencils(ctx context.Context, data stencilTypeData, byID bool) ([]string, error) {
+	stencilType := stencilTypes.valueOf(data.Type)
+
+	if stencilType != stencilTypes["partial"] && stencilType != stencilTypes["layout"] {
+		return nil, nil
+	}
+
+	clientResponse, err := s.provider.evaClient.ListMessageTemplates(ctx, eva.ListMessageTemplatesRequest{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ownIDs := map[int64]bool{data.ID.Value: true}
+
+	for _, variant := range data.Variants {
+		ownIDs[variant.ID.Value] = true
+	}
+
+	references := map[string]bool{data.Name.Value: true}
+
+	if byID {
+		references[fmt.Sprint(data.ID.Value)] = true
+	}
+
+	names := map[string]bool{}
+
+	for _, template := range clientResponse.Result {
+		if ownIDs[template.ID] {
+			continue
+		}
+
+		other, err := s.provider.evaClient.GetCachedMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{
+			ID: template.ID,
+		})
+
+		if errors.Is(err, eva.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if stencilType == stencilTypes["layout"] && references[other.Layout] {
+			names[other.Name] = true
+		}
+
+		if stencilType == stencilTypes["partial"] {
+			for _, partial := range referencedPartials([]string{other.Header, other.Template, other.Footer}) {
+				if references[partial] {
+					names[other.Name] = true
+				}
+			}
+		}
+	}
+
+	var sortedNames []string
+
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+
+	sort.Strings(sortedNames)
+
+	return sortedNames, nil
+}
+
+// validateReferencingStencils warns when a partial or layout which other stencils in EVA refer to is renamed or
+// destroyed, as their templates break until they no longer refer to it. References by ID only break on destroy.
+func (s stencil) validateReferencingStencils(ctx context.Context, state stencilTypeData, plan *stencilTypeData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx, cancel := state.Timeouts.read(ctx)
+	defer cancel()
+
+	names, err := s.getReferencingStencils(ctx, state, plan == nil)
+
+	if err != nil {
+		tflog.Info(ctx, "Unable to get the stencils which refer to the stencil.", "error", err)
+		return diags
+	}
+
+	if len(names) == 0 {
+		return diags
+	}
+
+	stencilType := stencilTypes.toName(stencilTypes.valueOf(state.Type))
+
+	if plan == nil {
+		diags.AddWarning(
+			fmt.Sprintf("Stencil %s is still referenced.", state.Name.Value),
+			fmt.Sprintf("Stencils %s refer to the %s, their templates break when it is destroyed. When they are updated in the same apply, make sure they depend on this stencil, so Terraform updates them before destroying it.", strings.Join(names, ", "), stencilType),
+		)
+	} else {
+		diags.AddAttributeWarning(
+			tftypes.NewAttributePath().WithAttributeName("name"),
+			fmt.Sprintf("Stencil %s is still referenced.", state.Name.Value),
+			fmt.Sprintf("Stencils %s refer to the %s by its current name, their templates break until they refer to %q. Terraform renames the stencil before updating the stencils which depend on it, to avoid broken templates in between, create a new stencil with the new name and remove this one once nothing refers to it anymore.", strings.Join(names, ", "), stencilType, plan.Name.Value),
+		)
+	}
+
+	return diags
+}
+
 type stencil struct {
 	provider provider
 }
 
+func (s stencil) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	resp.Diagnostics.Append(validateConflictingAttributes(req.Config, false, "organization_unit_id", "organization_unit_set_id")...)
+	resp.Diagnostics.Append(validateConflictingAttributes(req.Config, false, "destination", "destinations")...)
+
+	var destinations types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("destinations"), &destinations)...)
+
+	for _, element := range destinations.Elems {
+		destination, ok := element.(types.String)
+
+		if !ok || destination.Unknown {
+			continue
+		}
+
+		if _, ok := stencilDestinations.toValue(destination.Value); !ok {
+			resp.Diagnostics.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName("destinations"),
+				"Invalid value.",
+				fmt.Sprintf("Got %q, expected any of: %s.", destination.Value, strings.Join(stencilDestinations.names(), ", ")),
+			)
+		}
+	}
+
+	for _, name := range stencilContentNames {
+		resp.Diagnostics.Append(validateConflictingAttributes(req.Config, false, name, name+"_file")...)
+	}
+
+	var stencilVariant stencilVariantTypeData
+	var variantsList types.List
+	var variants []stencilVariantTypeData
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("language_id"), &stencilVariant.LanguageID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("country_id"), &stencilVariant.CountryID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("organization_unit_id"), &stencilVariant.OrganizationUnitID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, stencilVariantsPath, &variantsList)...)
+
+	if resp.Diagnostics.HasError() || variantsList.Null || variantsList.Unknown {
+		return
+	}
+
+	resp.Diagnostics.Append(variantsList.ElementsAs(ctx, &variants, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateStencilVariants(stencilVariant, variants)...)
+}
+
+// ModifyPlan validates the templates, and shows the changes to the contents of files as a diff, as only the hashes
+// of the files are in the plan.
+func (s stencil) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	var plan stencilTypeData
+	var state stencilTypeData
+
+	// Only stencils which refer to the stencil have to be checked when it is destroyed.
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(s.validateReferencingStencils(ctx, state, nil)...)
+		}
+
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(s.validateTemplates(ctx, plan)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("referenced_partials"), plan.getPlannedReferencedPartials())...)
+
+	// Nothing to compare with when the stencil is created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.Unknown && plan.Name.Value != state.Name.Value {
+		resp.Diagnostics.Append(s.validateReferencingStencils(ctx, state, &plan)...)
+	}
+
+	if len(plan.Variants) > 0 {
+		plan.matchVariants(state)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, stencilVariantsPath, plan.Variants)...)
+	}
+
+	var changedContents []stencilContent
+
+	stateContents := state.getStencilContents()
+
+	for i, content := range plan.getStencilContents() {
+		if !content.File.Null && !content.Hash.Unknown && !content.Hash.Equal(*stateContents[i].Hash) {
+			changedContents = append(changedContents, content)
+		}
+	}
+
+	if len(changedContents) == 0 {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.read(ctx)
+	defer cancel()
+
+	clientResponse, err := s.provider.evaClient.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{
+		ID: state.ID.Value,
+	})
+
+	if err != nil {
+		tflog.Info(ctx, "Unable to get the stencil to show the changes of the files.", "error", err)
+		return
+	}
+
+	currentContents := getEvaStencilContents(clientResponse)
+
+	for _, content := range changedContents {
+		file, err := os.ReadFile(content.File.Value)
+
+		// The error is already reported by the plan modifier of the hash.
+		if err != nil {
+			return
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(currentContents[content.Name]),
+			B:        difflib.SplitLines(string(file)),
+			FromFile: fmt.Sprintf("%s (EVA)", content.Name),
+			ToFile:   content.File.Value,
+			Context:  3,
+		})
+
+		if err != nil {
+			tflog.Info(ctx, "Unable to show the changes of the file.", "file", content.File.Value, "error", err)
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			tftypes.NewAttributePath().WithAttributeName(content.Name+"_file"),
+			fmt.Sprintf("The %s of stencil %s changes.", content.Name, plan.Name.Value),
+			diff,
+		)
+	}
+}
+
 func (s stencil) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data stencilTypeData
 
-	diags := req.Config.Get(ctx, &data)
+	// The plan holds the hashes of the files, these are checked against the files that are uploaded.
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	clientResponse, err := s.provider.evaClient.CreateMessageTemplate(ctx, eva.CreateMessageTemplateRequest{
-		Name:               data.Name.Value,
-		OrganizationUnitID: data.OrganizationUnitID.Value,
-		LanguageID:         data.LanguageID.Value,
-		CountryID:          data.CountryID.Value,
-		Header:             data.Header.Value,
-		Template:           data.Template.Value,
-		Footer:             data.Footer.Value,
-		Helpers:            data.Helpers.Value,
-		Type:               data.Type.Value,
-		Layout:             data.Layout.Value,
-		Destination:        data.Destination.Value,
-		PaperProperties: &eva.PaperProperties{
-			WaitForNetworkIdle:         data.PaperProperties.WaitForNetworkIdle.Value,
-			WaitForJS:                  data.PaperProperties.WaitForJS.Value,
-			Format:                     data.PaperProperties.Format.Value,
-			Orientation:                data.PaperProperties.Orientation.Value,
-			ThermalPrinterTemplateType: data.PaperProperties.ThermalPrinterTemplateType.Value,
-			Size: eva.PaperSize{
-				Width:             data.PaperProperties.Size.Width.Value,
-				Height:            data.PaperProperties.Size.Height.Value,
-				DeviceScaleFactor: data.PaperProperties.Size.DeviceScaleFactor.Value,
-			},
-			Margin: eva.PaperMargin{
-				Top:    data.PaperProperties.Margin.Top.Value,
-				Left:   data.PaperProperties.Margin.Left.Value,
-				Bottom: data.PaperProperties.Margin.Bottom.Value,
-				Right:  data.PaperProperties.Margin.Right.Value,
-			},
-		},
-	})
+	ctx, cancel := data.Timeouts.create(ctx)
+	defer cancel()
+
+	contents, err := data.readContents()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Reading stencil files failed.", err.Error())
+		return
+	}
+
+	clientResponse, err := s.provider.evaClient.CreateMessageTemplate(ctx, data.getEvaCreateMessageTemplateRequest(contents))
 
 	if err != nil {
 		resp.Diagnostics.AddError("Creating stencil unit failed.", fmt.Sprintf("Unable to create stencil, got error: %s", err))
@@ -298,6 +1070,17 @@ func (s stencil) Create(ctx context.Context, req tfsdk.CreateResourceRequest, re
 
 	tflog.Trace(ctx, "Created a stencil")
 
+	if len(data.Variants) > 0 {
+		var variants []stencilVariantTypeData
+
+		variants, diags = s.createVariants(ctx, data, contents, data.Variants)
+		resp.Diagnostics.Append(diags...)
+
+		data.Variants = variants
+	}
+
+	data.ReferencedPartials = data.getPlannedReferencedPartials()
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -312,96 +1095,93 @@ func (s stencil) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *
 		return
 	}
 
+	ctx, cancel := data.Timeouts.read(ctx)
+	defer cancel()
+
 	clientResponse, err := s.provider.evaClient.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{
 		ID: data.ID.Value,
 	})
 
+	if errors.Is(err, eva.ErrNotFound) {
+		tflog.Info(ctx, "The stencil no longer exists, removing it from the state.")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Getting stencil data failed.", fmt.Sprintf("Unable to get stencil, got error: %s", err))
 		return
 	}
 
 	data.Name = types.String{Value: clientResponse.Name}
-	data.OrganizationUnitID = types.Int64{Value: clientResponse.OrganizationUnitID}
+	data.OrganizationUnitID = int64ValueOrNull(clientResponse.OrganizationUnitID)
+	data.OrganizationUnitSetID = int64ValueOrNull(clientResponse.OrganizationUnitSetID)
 	data.LanguageID = types.String{Value: clientResponse.LanguageID}
 	data.CountryID = types.String{Value: clientResponse.CountryID}
-	data.Header = types.String{Value: clientResponse.Header}
-	data.Template = types.String{Value: clientResponse.Template}
-	data.Footer = types.String{Value: clientResponse.Footer}
-	data.Helpers = types.String{Value: clientResponse.Helpers}
-	data.Type = types.Int64{Value: clientResponse.Type}
+	data.setContents(getEvaStencilContents(clientResponse))
+	data.Type = stencilTypes.valueOrNull(data.Type, clientResponse.Type)
 	data.Layout = types.String{Value: clientResponse.Layout}
-	data.Destination = types.Int64{Value: clientResponse.Destination}
-	data.PaperProperties = &paperPropertiesTypeData{
-		WaitForNetworkIdle:         types.Bool{Value: clientResponse.PaperProperties.WaitForNetworkIdle},
-		WaitForJS:                  types.Bool{Value: clientResponse.PaperProperties.WaitForJS},
-		Format:                     types.Int64{Value: clientResponse.PaperProperties.Format},
-		Orientation:                types.Int64{Value: clientResponse.PaperProperties.Orientation},
-		ThermalPrinterTemplateType: types.Int64{Value: clientResponse.PaperProperties.ThermalPrinterTemplateType},
-		Size: &paperSizeTypeData{
-			Width:             types.String{Value: clientResponse.PaperProperties.Size.Width},
-			Height:            types.String{Value: clientResponse.PaperProperties.Size.Height},
-			DeviceScaleFactor: types.Float64{Value: clientResponse.PaperProperties.Size.DeviceScaleFactor},
-		},
-		Margin: &paperMarginTypeData{
-			Top:    types.Int64{Value: clientResponse.PaperProperties.Margin.Top},
-			Left:   types.Int64{Value: clientResponse.PaperProperties.Margin.Left},
-			Bottom: types.Int64{Value: clientResponse.PaperProperties.Margin.Bottom},
-			Right:  types.Int64{Value: clientResponse.PaperProperties.Margin.Right},
-		},
+
+	if data.Destinations != nil {
+		if stencilDestinations.flagsValue(data.Destinations) != clientResponse.Destination {
+			data.Destinations = stencilDestinations.flagsNames(clientResponse.Destination)
+		}
+	} else {
+		data.Destination = types.Int64{Value: clientResponse.Destination}
+	}
+
+	data.PaperProperties = getPaperPropertiesData(data.PaperProperties, clientResponse.PaperProperties)
+
+	variants, variantTemplates, diags := s.readVariants(ctx, data.Variants)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	data.Variants = variants
+	data.ReferencedPartials = getReferencedPartials(append([]string{clientResponse.Header, clientResponse.Template, clientResponse.Footer}, variantTemplates...))
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (s stencil) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
 	var plan stencilTypeData
+	var state stencilTypeData
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := s.provider.evaClient.UpdateMessageTemplate(ctx, eva.UpdateMessageTemplateRequest{
-		ID:                 plan.ID.Value,
-		Name:               plan.Name.Value,
-		OrganizationUnitID: plan.OrganizationUnitID.Value,
-		LanguageID:         plan.LanguageID.Value,
-		CountryID:          plan.CountryID.Value,
-		Header:             plan.Header.Value,
-		Template:           plan.Template.Value,
-		Footer:             plan.Footer.Value,
-		Helpers:            plan.Helpers.Value,
-		Layout:             plan.Layout.Value,
-		Destination:        plan.Destination.Value,
-		PaperProperties: &eva.PaperProperties{
-			WaitForNetworkIdle:         plan.PaperProperties.WaitForNetworkIdle.Value,
-			WaitForJS:                  plan.PaperProperties.WaitForJS.Value,
-			Format:                     plan.PaperProperties.Format.Value,
-			Orientation:                plan.PaperProperties.Orientation.Value,
-			ThermalPrinterTemplateType: plan.PaperProperties.ThermalPrinterTemplateType.Value,
-			Size: eva.PaperSize{
-				Width:             plan.PaperProperties.Size.Width.Value,
-				Height:            plan.PaperProperties.Size.Height.Value,
-				DeviceScaleFactor: plan.PaperProperties.Size.DeviceScaleFactor.Value,
-			},
-			Margin: eva.PaperMargin{
-				Top:    plan.PaperProperties.Margin.Top.Value,
-				Left:   plan.PaperProperties.Margin.Left.Value,
-				Bottom: plan.PaperProperties.Margin.Bottom.Value,
-				Right:  plan.PaperProperties.Margin.Right.Value,
-			},
-		},
-	})
+	ctx, cancel := plan.Timeouts.update(ctx)
+	defer cancel()
+
+	contents, err := plan.readContents()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Reading stencil files failed.", err.Error())
+		return
+	}
+
+	_, err = s.provider.evaClient.UpdateMessageTemplate(ctx, plan.getEvaUpdateMessageTemplateRequest(contents))
 
 	if err != nil {
 		resp.Diagnostics.AddError("Updating stencil unit failed.", fmt.Sprintf("Unable to update stencil, got error: %s", err))
 		return
 	}
 
+	plan.Variants, diags = s.updateVariants(ctx, plan, state, contents)
+	resp.Diagnostics.Append(diags...)
+
+	plan.ReferencedPartials = plan.getPlannedReferencedPartials()
+
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -416,6 +1196,15 @@ func (s stencil) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, re
 		return
 	}
 
+	ctx, cancel := data.Timeouts.delete(ctx)
+	defer cancel()
+
+	resp.Diagnostics.Append(s.deleteVariants(ctx, data.Variants)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	_, err := s.provider.evaClient.DeleteMessageTemplate(ctx, eva.DeleteMessageTemplateRequesst{
 		ID: data.ID.Value,
 	})
@@ -0,0 +1,742 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func int64Ref(value int64) *int64 {
+	return &value
+}
+
+func float64Ref(value float64) *float64 {
+	return &value
+}
+
+func TestStencilGetEvaPaperProperties(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     *paperPropertiesTypeData
+		expected *eva.PaperProperties
+	}{
+		{
+			name:     "not configured",
+			data:     nil,
+			expected: nil,
+		},
+		{
+			name: "optional properties not configured",
+			data: &paperPropertiesTypeData{
+				WaitForNetworkIdle:         types.Bool{Value: true},
+				WaitForJS:                  types.Bool{Value: false},
+				Format:                     types.String{Null: true},
+				Orientation:                types.String{Null: true},
+				ThermalPrinterTemplateType: types.Int64{Null: true},
+				Size: &paperSizeTypeData{
+					Width:             types.String{Value: "100"},
+					Height:            types.String{Value: "500"},
+					DeviceScaleFactor: types.Float64{Null: true},
+				},
+			},
+			expected: &eva.PaperProperties{
+				WaitForNetworkIdle: true,
+				Size: &eva.PaperSize{
+					Width:  "100",
+					Height: "500",
+				},
+			},
+		},
+		{
+			name: "all properties configured",
+			data: &paperPropertiesTypeData{
+				WaitForNetworkIdle:         types.Bool{Value: true},
+				WaitForJS:                  types.Bool{Value: true},
+				Format:                     types.String{Value: "A4"},
+				Orientation:                types.String{Value: "2"},
+				ThermalPrinterTemplateType: types.Int64{Value: 1},
+				Size: &paperSizeTypeData{
+					Width:             types.String{Value: "100"},
+					Height:            types.String{Value: "500"},
+					DeviceScaleFactor: types.Float64{Value: 1.5},
+				},
+				Margin: &paperMarginTypeData{
+					Top:    types.Int64{Value: 0},
+					Left:   types.Int64{Value: 10},
+					Bottom: types.Int64{Value: 0},
+					Right:  types.Int64{Null: true},
+				},
+			},
+			expected: &eva.PaperProperties{
+				WaitForNetworkIdle:         true,
+				WaitForJS:                  true,
+				Format:                     int64Ref(2),
+				Orientation:                int64Ref(2),
+				ThermalPrinterTemplateType: int64Ref(1),
+				Size: &eva.PaperSize{
+					Width:             "100",
+					Height:            "500",
+					DeviceScaleFactor: float64Ref(1.5),
+				},
+				Margin: &eva.PaperMargin{
+					Top:    int64Ref(0),
+					Left:   int64Ref(10),
+					Bottom: int64Ref(0),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := stencilTypeData{PaperProperties: testCase.data}.getEvaPaperProperties()
+
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %s, got %s", toJSON(testCase.expected), toJSON(actual))
+			}
+		})
+	}
+}
+
+func TestStencilGetPaperPropertiesData(t *testing.T) {
+	testCases := []struct {
+		name       string
+		current    *paperPropertiesTypeData
+		properties *eva.PaperProperties
+		expected   *paperPropertiesTypeData
+	}{
+		{
+			name:       "not returned",
+			current:    nil,
+			properties: nil,
+			expected:   nil,
+		},
+		{
+			name:    "not configured and zero values returned",
+			current: nil,
+			properties: &eva.PaperProperties{
+				Format: int64Ref(0),
+				Size:   &eva.PaperSize{},
+				Margin: &eva.PaperMargin{Top: int64Ref(0), Left: int64Ref(0)},
+			},
+			expected: nil,
+		},
+		{
+			name: "margin not configured and zero margin returned",
+			current: &paperPropertiesTypeData{
+				Format:                     types.String{Value: "A4"},
+				Orientation:                types.String{Null: true},
+				ThermalPrinterTemplateType: types.Int64{Null: true},
+				Size: &paperSizeTypeData{
+					DeviceScaleFactor: types.Float64{Null: true},
+				},
+			},
+			properties: &eva.PaperProperties{
+				Format:                     int64Ref(2),
+				Orientation:                int64Ref(0),
+				ThermalPrinterTemplateType: int64Ref(0),
+				Size:                       &eva.PaperSize{Width: "100", Height: "500", DeviceScaleFactor: float64Ref(0)},
+				Margin:                     &eva.PaperMargin{Top: int64Ref(0), Left: int64Ref(0), Bottom: int64Ref(0), Right: int64Ref(0)},
+			},
+			expected: &paperPropertiesTypeData{
+				WaitForNetworkIdle:         types.Bool{Value: false},
+				WaitForJS:                  types.Bool{Value: false},
+				Format:                     types.String{Value: "A4"},
+				Orientation:                types.String{Null: true},
+				ThermalPrinterTemplateType: types.Int64{Null: true},
+				Size: &paperSizeTypeData{
+					Width:             types.String{Value: "100"},
+					Height:            types.String{Value: "500"},
+					DeviceScaleFactor: types.Float64{Null: true},
+				},
+			},
+		},
+		{
+			name: "zero values configured",
+			current: &paperPropertiesTypeData{
+				Format:                     types.String{Value: "2"},
+				Orientation:                types.String{Null: true},
+				ThermalPrinterTemplateType: types.Int64{Value: 0},
+				Size: &paperSizeTypeData{
+					DeviceScaleFactor: types.Float64{Value: 0},
+				},
+				Margin: &paperMarginTypeData{
+					Bottom: types.Int64{Value: 0},
+					Right:  types.Int64{Null: true},
+				},
+			},
+			properties: &eva.PaperProperties{
+				Format:                     int64Ref(2),
+				ThermalPrinterTemplateType: int64Ref(0),
+				Size:                       &eva.PaperSize{Width: "100", Height: "500", DeviceScaleFactor: float64Ref(0)},
+				Margin:                     &eva.PaperMargin{Top: int64Ref(0), Left: int64Ref(0), Bottom: int64Ref(0), Right: int64Ref(0)},
+			},
+			expected: &paperPropertiesTypeData{
+				WaitForNetworkIdle:         types.Bool{Value: false},
+				WaitForJS:                  types.Bool{Value: false},
+				Format:                     types.String{Value: "2"},
+				Orientation:                types.String{Null: true},
+				ThermalPrinterTemplateType: types.Int64{Value: 0},
+				Size: &paperSizeTypeData{
+					Width:             types.String{Value: "100"},
+					Height:            types.String{Value: "500"},
+					DeviceScaleFactor: types.Float64{Value: 0},
+				},
+				Margin: &paperMarginTypeData{
+					Top:    types.Int64{Value: 0},
+					Left:   types.Int64{Value: 0},
+					Bottom: types.Int64{Value: 0},
+					Right:  types.Int64{Null: true},
+				},
+			},
+		},
+		{
+			name:    "imported",
+			current: nil,
+			properties: &eva.PaperProperties{
+				WaitForJS:   true,
+				Format:      int64Ref(2),
+				Orientation: int64Ref(2),
+				Size:        &eva.PaperSize{Width: "100", Height: "500"},
+				Margin:      &eva.PaperMargin{Top: int64Ref(5), Left: int64Ref(5)},
+			},
+			expected: &paperPropertiesTypeData{
+				WaitForNetworkIdle:         types.Bool{Value: false},
+				WaitForJS:                  types.Bool{Value: true},
+				Format:                     types.String{Value: "A4"},
+				Orientation:                types.String{Value: "landscape"},
+				ThermalPrinterTemplateType: types.Int64{Null: true},
+				Size: &paperSizeTypeData{
+					Width:             types.String{Value: "100"},
+					Height:            types.String{Value: "500"},
+					DeviceScaleFactor: types.Float64{Null: true},
+				},
+				Margin: &paperMarginTypeData{
+					Top:    types.Int64{Value: 5},
+					Left:   types.Int64{Value: 5},
+					Bottom: types.Int64{Null: true},
+					Right:  types.Int64{Null: true},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := getPaperPropertiesData(testCase.current, testCase.properties)
+
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %s, got %s", toJSON(testCase.expected), toJSON(actual))
+			}
+		})
+	}
+}
+
+func TestStencilReadContentsChecksPlannedHash(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "template.hbs")
+
+	if err := os.WriteFile(file, []byte("<p>planned</p>"), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		name          string
+		plannedHash   types.String
+		contents      string
+		expectedError string
+	}{
+		{
+			name:        "unchanged",
+			plannedHash: types.String{Value: sha256Hex("<p>planned</p>")},
+			contents:    "<p>planned</p>",
+		},
+		{
+			name:        "hash unknown",
+			plannedHash: types.String{Unknown: true},
+			contents:    "<p>changed</p>",
+		},
+		{
+			name:          "changed after the plan",
+			plannedHash:   types.String{Value: sha256Hex("<p>planned</p>")},
+			contents:      "<p>changed</p>",
+			expectedError: "changed since the plan",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := os.WriteFile(file, []byte(testCase.contents), 0600); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			data := stencilTypeData{
+				TemplateFile:       types.String{Value: file},
+				TemplateFileSHA256: testCase.plannedHash,
+				HeaderFile:         types.String{Null: true},
+				FooterFile:         types.String{Null: true},
+				HelpersFile:        types.String{Null: true},
+			}
+			variant := stencilVariantTypeData{
+				TemplateFile:       types.String{Value: file},
+				TemplateFileSHA256: testCase.plannedHash,
+			}
+
+			contents, err := data.readContents()
+			variantContents, variantErr := variant.readContents(map[string]string{})
+
+			if testCase.expectedError == "" {
+				if err != nil || variantErr != nil {
+					t.Fatalf("unexpected errors: %v, %v", err, variantErr)
+				}
+
+				if contents["template"] != testCase.contents || variantContents["template"] != testCase.contents {
+					t.Errorf("expected the contents %q to be uploaded, got %q and %q", testCase.contents, contents["template"], variantContents["template"])
+				}
+
+				if data.TemplateFileSHA256.Value != sha256Hex(testCase.contents) || variant.TemplateFileSHA256.Value != sha256Hex(testCase.contents) {
+					t.Errorf("expected the hashes of the uploaded contents, got %v and %v", data.TemplateFileSHA256, variant.TemplateFileSHA256)
+				}
+				return
+			}
+
+			for _, err := range []error{err, variantErr} {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlebarsValidator(t *testing.T) {
+	testCases := []struct {
+		name             string
+		template         types.String
+		expectedSeverity diag.Severity
+	}{
+		{
+			name:     "valid template",
+			template: types.String{Value: `<p>{{Order.ID}}</p>{{> address Order.ShippingAddress}}`},
+		},
+		{
+			name:     "unknown template",
+			template: types.String{Unknown: true},
+		},
+		{
+			name:             "partial block",
+			template:         types.String{Value: `{{#> layout}}<p>{{Order.ID}}</p>{{/layout}}`},
+			expectedSeverity: diag.SeverityWarning,
+		},
+		{
+			name:             "inline partial",
+			template:         types.String{Value: `{{#*inline "content"}}<p>{{Order.ID}}</p>{{/inline}}`},
+			expectedSeverity: diag.SeverityWarning,
+		},
+		{
+			name:             "invalid template",
+			template:         types.String{Value: `<p>{{Order.ID</p>`},
+			expectedSeverity: diag.SeverityWarning,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := tfsdk.ValidateAttributeResponse{}
+
+			handlebarsValidator{}.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+				AttributePath:   tftypes.NewAttributePath().WithAttributeName("template"),
+				AttributeConfig: testCase.template,
+			}, &resp)
+
+			if testCase.expectedSeverity == diag.SeverityInvalid {
+				if len(resp.Diagnostics) != 0 {
+					t.Errorf("expected no diagnostics, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity() != testCase.expectedSeverity {
+				t.Errorf("expected a diagnostic with severity %s, got %v", testCase.expectedSeverity, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestStencilPlannedReferencedPartials(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     stencilTypeData
+		expected types.List
+	}{
+		{
+			name: "stencil and variants",
+			data: func() stencilTypeData {
+				data := testStencilData(testStencilVariant(types.Int64{Unknown: true}, "nl", "NL", `{{> footer}}{{> address}}`))
+				data.Header = types.String{Value: `{{> logo}}`}
+				data.Template = types.String{Value: `{{> address Order.ShippingAddress}}{{> (lookup . "dynamic")}}`}
+				return data
+			}(),
+			expected: testStringList("address", "footer", "logo"),
+		},
+		{
+			name: "template which can't be parsed",
+			data: func() stencilTypeData {
+				data := testStencilData()
+				data.Header = types.String{Value: `{{> logo}}`}
+				data.Template = types.String{Value: `{{#> layout}}{{> address}}{{/layout}}`}
+				return data
+			}(),
+			expected: testStringList("logo"),
+		},
+		{
+			name:     "no partials",
+			data:     testStencilData(),
+			expected: testStringList(),
+		},
+		{
+			name: "unknown template",
+			data: func() stencilTypeData {
+				data := testStencilData()
+				data.Template = types.String{Unknown: true}
+				return data
+			}(),
+			expected: types.List{ElemType: types.StringType, Unknown: true},
+		},
+		{
+			name: "unknown variant template",
+			data: func() stencilTypeData {
+				variant := testStencilVariant(types.Int64{Unknown: true}, "nl", "NL", "")
+				variant.Template = types.String{Unknown: true}
+				return testStencilData(variant)
+			}(),
+			expected: types.List{ElemType: types.StringType, Unknown: true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := testCase.data.getPlannedReferencedPartials()
+
+			if !actual.Equal(testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStencilReadReferencedPartials(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	s := stencil{provider: testProvider(server)}
+	schema, _ := stencilType{}.GetSchema(ctx)
+
+	planned := testStencilData(testStencilVariant(types.Int64{Unknown: true}, "nl", "NL", `{{> address}}{{> footer}}`))
+	planned.Template = types.String{Value: `{{> address}}`}
+
+	plan := tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, &planned)
+
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	s.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+
+	for _, d := range createResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var created stencilTypeData
+
+	createResp.State.Get(ctx, &created)
+
+	if expected := testStringList("address", "footer"); !created.ReferencedPartials.Equal(expected) {
+		t.Errorf("expected the referenced partials %v after create, got %v", expected, created.ReferencedPartials)
+	}
+
+	// The template of the variant is changed outside of Terraform.
+	variant, _ := client.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{ID: created.Variants[0].ID.Value})
+	updateRequest := created.getVariant(created.Variants[0]).getEvaUpdateMessageTemplateRequest(map[string]string{"template": `{{> header}}`})
+	updateRequest.Name = variant.Name
+
+	if _, err := client.UpdateMessageTemplate(ctx, updateRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: createResp.State}
+	s.Read(ctx, tfsdk.ReadResourceRequest{State: createResp.State}, &readResp)
+
+	for _, d := range readResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var read stencilTypeData
+
+	readResp.State.Get(ctx, &read)
+
+	if expected := testStringList("address", "header"); !read.ReferencedPartials.Equal(expected) {
+		t.Errorf("expected the referenced partials %v after read, got %v", expected, read.ReferencedPartials)
+	}
+}
+
+func TestStencilReadOrganizationUnitSet(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	s := stencil{provider: testProvider(server)}
+	schema, _ := stencilType{}.GetSchema(ctx)
+
+	planned := testStencilData()
+	planned.OrganizationUnitID = types.Int64{Null: true}
+	planned.OrganizationUnitSetID = types.Int64{Value: 5}
+
+	plan := tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, &planned)
+
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	s.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+
+	for _, d := range createResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: createResp.State}
+	s.Read(ctx, tfsdk.ReadResourceRequest{State: createResp.State}, &readResp)
+
+	for _, d := range readResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var read stencilTypeData
+
+	readResp.State.Get(ctx, &read)
+
+	if !read.OrganizationUnitID.Null || !read.OrganizationUnitSetID.Equal(types.Int64{Value: 5}) {
+		t.Errorf("expected the stencil to only target organization unit set 5, got organization unit %v and set %v", read.OrganizationUnitID, read.OrganizationUnitSetID)
+	}
+}
+
+func TestStencilValidateTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	s := stencil{provider: testProvider(server)}
+
+	testCreateStencil(t, server, "address", stencilTypes["partial"], "", "<p>{{Street}}</p>")
+	testCreateStencil(t, server, "base", stencilTypes["layout"], "", "{{{body}}}")
+
+	testCases := []struct {
+		name          string
+		template      string
+		layout        string
+		expectedPaths []*tftypes.AttributePath
+	}{
+		{
+			name:     "existing partial and layout",
+			template: `{{> address}}`,
+			layout:   "base",
+		},
+		{
+			name:          "missing partial",
+			template:      `{{> address}}{{> missing}}`,
+			layout:        "base",
+			expectedPaths: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("template")},
+		},
+		{
+			name:          "missing layout",
+			template:      `{{> address}}`,
+			layout:        "missing",
+			expectedPaths: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("layout")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := testStencilData()
+			data.Template = types.String{Value: testCase.template}
+			data.Layout = types.String{Value: testCase.layout}
+
+			// The result doesn't depend on other stencils which are planned before, so check it twice.
+			for i := 0; i < 2; i++ {
+				diags := s.validateTemplates(ctx, data)
+
+				if len(diags) != len(testCase.expectedPaths) {
+					t.Fatalf("expected %d diagnostics, got %v", len(testCase.expectedPaths), diags)
+				}
+
+				for i, d := range diags {
+					withPath, ok := d.(interface{ Path() *tftypes.AttributePath })
+
+					if d.Severity() != diag.SeverityWarning || !ok || !withPath.Path().Equal(testCase.expectedPaths[i]) {
+						t.Errorf("expected a warning on %s, got %s: %s", testCase.expectedPaths[i], d.Summary(), d.Detail())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStencilValidateReferencingStencils(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	s := stencil{provider: testProvider(server)}
+
+	address := testCreateStencil(t, server, "address", stencilTypes["partial"], "", "<p>{{Street}}</p>")
+	logo := testCreateStencil(t, server, "logo", stencilTypes["partial"], "", "<img>")
+	base := testCreateStencil(t, server, "base", stencilTypes["layout"], "", "{{{body}}}")
+	testCreateStencil(t, server, "Order confirmation", stencilTypes["template"], "base", "{{> address}}")
+	testCreateStencil(t, server, "Invoice", stencilTypes["template"], "", fmt.Sprintf("{{> %d}}", address.ID.Value))
+
+	renamed := func(data stencilTypeData) *stencilTypeData {
+		data.Name = types.String{Value: "renamed"}
+		return &data
+	}
+
+	testCases := []struct {
+		name             string
+		state            stencilTypeData
+		plan             *stencilTypeData
+		expectedPath     *tftypes.AttributePath
+		expectedStencils string
+	}{
+		{
+			name:             "partial destroyed",
+			state:            address,
+			expectedStencils: "Invoice, Order confirmation",
+		},
+		{
+			// Invoice refers to the partial by its ID, which doesn't change.
+			name:             "partial renamed",
+			state:            address,
+			plan:             renamed(address),
+			expectedPath:     tftypes.NewAttributePath().WithAttributeName("name"),
+			expectedStencils: "Order confirmation",
+		},
+		{
+			name:             "layout destroyed",
+			state:            base,
+			expectedStencils: "Order confirmation",
+		},
+		{
+			name:  "partial which is not referenced",
+			state: logo,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diags := s.validateReferencingStencils(ctx, testCase.state, testCase.plan)
+
+			if testCase.expectedStencils == "" {
+				if len(diags) != 0 {
+					t.Errorf("expected no diagnostics, got %v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Severity() != diag.SeverityWarning || !strings.Contains(diags[0].Detail(), "Stencils "+testCase.expectedStencils+" refer") {
+				t.Fatalf("expected a warning about %s, got %v", testCase.expectedStencils, diags)
+			}
+
+			withPath, ok := diags[0].(interface{ Path() *tftypes.AttributePath })
+
+			if testCase.expectedPath != nil && (!ok || !withPath.Path().Equal(testCase.expectedPath)) {
+				t.Errorf("expected a warning on %s, got %v", testCase.expectedPath, diags[0])
+			}
+		})
+	}
+}
+
+func TestStencilValidateReferencingStencilsReadsStencilsOnce(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	s := stencil{provider: testProvider(server)}
+
+	var partials []stencilTypeData
+
+	for _, name := range []string{"address", "logo", "footer"} {
+		partials = append(partials, testCreateStencil(t, server, name, stencilTypes["partial"], "", "<p></p>"))
+	}
+
+	testCreateStencil(t, server, "Order confirmation", stencilTypes["template"], "", "{{> address}}")
+
+	// Destroying all partials reads every stencil once, instead of once per destroyed partial.
+	for _, partial := range partials {
+		s.validateReferencingStencils(ctx, partial, nil)
+	}
+
+	if requests := server.Requests("/api/core/management/ListMessageTemplates"); requests != 1 {
+		t.Errorf("expected the stencils to be listed once, got %d requests", requests)
+	}
+
+	if requests := server.Requests("/api/core/management/GetMessageTemplateByID"); requests != 4 {
+		t.Errorf("expected each of the 4 stencils to be read once, got %d requests", requests)
+	}
+
+	// Only templates are checked when the stencil isn't a partial or layout.
+	s.validateReferencingStencils(ctx, testCreateStencil(t, server, "Invoice", stencilTypes["template"], "", "<p></p>"), nil)
+
+	if requests := server.Requests("/api/core/management/ListMessageTemplates"); requests != 1 {
+		t.Errorf("expected no stencils to be listed for a template, got %d requests", requests)
+	}
+}
+
+// testCreateStencil creates a stencil in the fake EVA API, and returns it as it is stored in the state.
+func testCreateStencil(t *testing.T, server *evatest.Server, name string, stencilType int64, layout string, template string) stencilTypeData {
+	data := testStencilData()
+	data.Name = types.String{Value: name}
+	data.Type = types.String{Value: stencilTypes.toName(stencilType)}
+	data.Layout = types.String{Value: layout}
+	data.Template = types.String{Value: template}
+
+	clientResponse, err := server.NewClient().CreateMessageTemplate(context.Background(), data.getEvaCreateMessageTemplateRequest(map[string]string{"template": template}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data.ID = types.Int64{Value: clientResponse.ID}
+
+	return data
+}
+
+func testStringList(values ...string) types.List {
+	list := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+
+	for _, value := range values {
+		list.Elems = append(list.Elems, types.String{Value: value})
+	}
+
+	return list
+}
+
+func toJSON(value interface{}) string {
+	result, _ := json.Marshal(value)
+
+	return string(result)
+}
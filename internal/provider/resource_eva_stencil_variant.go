@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+)
+
+var stencilVariantsPath = tftypes.NewAttributePath().WithAttributeName("variants")
+
+// stencilVariantsAttribute returns the attribute to configure the variants of a stencil.
+func stencilVariantsAttribute() tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: "Variants of the stencil for other languages, countries or organization units. A variant is a separate stencil in EVA with the same name, header, footer, helpers, layout and paper properties, only the template can be overridden.",
+		Optional:            true,
+		Attributes: tfsdk.ListNestedAttributes(
+			map[string]tfsdk.Attribute{
+				"id": {
+					MarkdownDescription: "ID of the stencil of the variant.",
+					Computed:            true,
+					Type:                types.Int64Type,
+				},
+				"language_id": {
+					MarkdownDescription: "Language unique identifier of the variant",
+					Optional:            true,
+					Type:                types.StringType,
+				},
+				"country_id": {
+					MarkdownDescription: "Country unique identifier of the variant",
+					Optional:            true,
+					Type:                types.StringType,
+				},
+				"organization_unit_id": {
+					MarkdownDescription: "Organization that the variant belongs to",
+					Optional:            true,
+					Type:                types.Int64Type,
+				},
+				"template": {
+					MarkdownDescription: "Template of the variant, defaults to the template of the stencil. Conflicts with `template_file`.",
+					Optional:            true,
+					Type:                types.StringType,
+					Validators: []tfsdk.AttributeValidator{
+						handlebarsValidator{},
+					},
+				},
+				"template_file": {
+					MarkdownDescription: "Path of a file with the template of the variant, only a hash of the contents is stored in the state. Conflicts with `template`.",
+					Optional:            true,
+					Type:                types.StringType,
+				},
+				"template_file_sha256": {
+					MarkdownDescription: "SHA-256 of the contents of `template_file`.",
+					Computed:            true,
+					Type:                types.StringType,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						fileHashModifier{File: "template_file"},
+					},
+				},
+			},
+			tfsdk.ListNestedAttributesOptions{},
+		),
+	}
+}
+
+type stencilVariantTypeData struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	LanguageID         types.String `tfsdk:"language_id"`
+	CountryID          types.String `tfsdk:"country_id"`
+	OrganizationUnitID types.Int64  `tfsdk:"organization_unit_id"`
+	Template           types.String `tfsdk:"template"`
+	TemplateFile       types.String `tfsdk:"template_file"`
+	TemplateFileSHA256 types.String `tfsdk:"template_file_sha256"`
+}
+
+// getVariant returns the stencil as it is created in EVA for the variant, so with the ID, language, country,
+// organization unit and template of the variant.
+func (d stencilTypeData) getVariant(variant stencilVariantTypeData) stencilTypeData {
+	d.ID = variant.ID
+	d.LanguageID = variant.LanguageID
+	d.CountryID = variant.CountryID
+	d.OrganizationUnitID = variant.OrganizationUnitID
+	d.OrganizationUnitSetID = types.Int64{Null: true}
+
+	return d
+}
+
+// key identifies the variant, there can only be one variant per language, country and organization unit.
+func (v stencilVariantTypeData) key() string {
+	return fmt.Sprintf("%s/%s/%d", v.LanguageID.Value, v.CountryID.Value, v.OrganizationUnitID.Value)
+}
+
+// readContents returns the contents of the variant, these are the contents of the stencil with the template of the variant.
+// The hash of the template file is updated to the contents that were read.
+func (v *stencilVariantTypeData) readContents(stencilContents map[string]string) (map[string]string, error) {
+	contents := map[string]string{}
+
+	for name, content := range stencilContents {
+		contents[name] = content
+	}
+
+	plannedHash := v.TemplateFileSHA256
+	v.TemplateFileSHA256 = types.String{Null: true}
+
+	if !v.TemplateFile.Null {
+		file, err := readStencilFile(v.TemplateFile, plannedHash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		contents["template"] = file
+		v.TemplateFileSHA256 = types.String{Value: sha256Hex(file)}
+	} else if !v.Template.Null {
+		contents["template"] = v.Template.Value
+	}
+
+	return contents, nil
+}
+
+// setVariant sets the variant as returned by EVA. A variant without template uses the template of the stencil,
+// so its template is only read when it is configured.
+func (v *stencilVariantTypeData) setVariant(template *eva.GetMessageTemplateByIDResponse) {
+	v.LanguageID = stringValueOrNull(template.LanguageID)
+	v.CountryID = stringValueOrNull(template.CountryID)
+	v.OrganizationUnitID = int64ValueOrNull(template.OrganizationUnitID)
+
+	if !v.TemplateFile.Null {
+		v.TemplateFileSHA256 = types.String{Value: sha256Hex(template.Template)}
+	} else if !v.Template.Null {
+		v.Template = types.String{Value: template.Template}
+	}
+}
+
+// validateStencilVariants checks that no variant has the same language, country and organization unit as the stencil
+// or another variant, and that the template of a variant is configured only once.
+func validateStencilVariants(stencil stencilVariantTypeData, variants []stencilVariantTypeData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The stencil itself counts as a variant, so no variant can have the same language, country and organization unit.
+	keys := map[string]bool{}
+
+	if !stencil.LanguageID.Unknown && !stencil.CountryID.Unknown && !stencil.OrganizationUnitID.Unknown {
+		keys[stencil.key()] = true
+	}
+
+	for i, variant := range variants {
+		path := stencilVariantsPath.WithElementKeyInt(i)
+
+		if !variant.Template.Null && !variant.TemplateFile.Null {
+			diags.AddAttributeError(path.WithAttributeName("template_file"), "Conflicting attributes.", "Only one of template, template_file can be configured.")
+		}
+
+		if variant.LanguageID.Unknown || variant.CountryID.Unknown || variant.OrganizationUnitID.Unknown {
+			continue
+		}
+
+		if keys[variant.key()] {
+			diags.AddAttributeError(path, "Duplicate variant.", fmt.Sprintf("There is already a variant for language %q, country %q and organization unit %d.", variant.LanguageID.Value, variant.CountryID.Value, variant.OrganizationUnitID.Value))
+		}
+
+		keys[variant.key()] = true
+	}
+
+	return diags
+}
+
+// matchVariants sets the IDs of the planned variants to the IDs of the variants in the state. Variants are matched
+// on their language, country and organization unit instead of their position in the list, so existing variants keep
+// their ID and new variants are created. Terraform copies the computed ID of the variant at the same position in the
+// state, so the ID of a new variant is reset to unknown.
+func (d *stencilTypeData) matchVariants(state stencilTypeData) {
+	variantIDs := map[string]types.Int64{}
+
+	for _, variant := range state.Variants {
+		variantIDs[variant.key()] = variant.ID
+	}
+
+	for i, variant := range d.Variants {
+		if id, ok := variantIDs[variant.key()]; ok {
+			d.Variants[i].ID = id
+		} else {
+			d.Variants[i].ID = types.Int64{Unknown: true}
+		}
+	}
+}
+
+// readVariants refreshes the variants from EVA, and returns their templates.
+func (s stencil) readVariants(ctx context.Context, variants []stencilVariantTypeData) ([]stencilVariantTypeData, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var read []stencilVariantTypeData
+	var templates []string
+
+	for _, variant := range variants {
+		clientResponse, err := s.provider.evaClient.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{
+			ID: variant.ID.Value,
+		})
+
+		// Variants removed outside of Terraform are left out, so they are planned to be created again.
+		if errors.Is(err, eva.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			diags.AddError("Getting stencil variant failed.", fmt.Sprintf("Unable to get variant %s, got error: %s", variant.key(), err))
+			return nil, nil, diags
+		}
+
+		variant.setVariant(clientResponse)
+		read = append(read, variant)
+		templates = append(templates, clientResponse.Template)
+	}
+
+	return read, templates, diags
+}
+
+// createVariants creates the variants in EVA. Variants that could not be created are left out of the returned
+// variants, so they are planned to be created again.
+func (s stencil) createVariants(ctx context.Context, data stencilTypeData, contents map[string]string, variants []stencilVariantTypeData) ([]stencilVariantTypeData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var created []stencilVariantTypeData
+
+	for _, variant := range variants {
+		variantContents, err := variant.readContents(contents)
+
+		if err != nil {
+			diags.AddError("Reading stencil files failed.", err.Error())
+			continue
+		}
+
+		clientResponse, err := s.provider.evaClient.CreateMessageTemplate(ctx, data.getVariant(variant).getEvaCreateMessageTemplateRequest(variantContents))
+
+		if err != nil {
+			diags.AddError("Creating stencil variant failed.", fmt.Sprintf("Unable to create variant %s, got error: %s", variant.key(), err))
+			continue
+		}
+
+		variant.ID = types.Int64{Value: clientResponse.ID}
+		created = append(created, variant)
+	}
+
+	return created, diags
+}
+
+// updateVariants deletes the variants of the state which are no longer planned, updates the variants which are still
+// planned and creates the new ones. The variants are returned in the planned order, followed by the variants which
+// could not be deleted, so their deletion is planned again.
+func (s stencil) updateVariants(ctx context.Context, plan stencilTypeData, state stencilTypeData, contents map[string]string) ([]stencilVariantTypeData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	plannedVariants := map[string]bool{}
+	var variants []stencilVariantTypeData
+	var undeletedVariants []stencilVariantTypeData
+
+	for _, variant := range plan.Variants {
+		plannedVariants[variant.key()] = true
+	}
+
+	// Variants are deleted first, so a variant can move to another language, country or organization unit.
+	for _, variant := range state.Variants {
+		if plannedVariants[variant.key()] {
+			continue
+		}
+
+		_, err := s.provider.evaClient.DeleteMessageTemplate(ctx, eva.DeleteMessageTemplateRequesst{
+			ID: variant.ID.Value,
+		})
+
+		if err != nil && !errors.Is(err, eva.ErrNotFound) {
+			diags.AddError("Deleting stencil variant failed.", fmt.Sprintf("Unable to delete variant %s, got error: %s", variant.key(), err))
+			undeletedVariants = append(undeletedVariants, variant)
+		}
+	}
+
+	for _, variant := range plan.Variants {
+		if variant.ID.Unknown || variant.ID.Null {
+			createdVariants, createDiags := s.createVariants(ctx, plan, contents, []stencilVariantTypeData{variant})
+			diags.Append(createDiags...)
+			variants = append(variants, createdVariants...)
+			continue
+		}
+
+		variantContents, err := variant.readContents(contents)
+
+		if err == nil {
+			_, err = s.provider.evaClient.UpdateMessageTemplate(ctx, plan.getVariant(variant).getEvaUpdateMessageTemplateRequest(variantContents))
+		}
+
+		if err != nil {
+			diags.AddError("Updating stencil variant failed.", fmt.Sprintf("Unable to update variant %s, got error: %s", variant.key(), err))
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return append(variants, undeletedVariants...), diags
+}
+
+// deleteVariants deletes the variants from EVA, variants which were already removed are skipped.
+func (s stencil) deleteVariants(ctx context.Context, variants []stencilVariantTypeData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, variant := range variants {
+		_, err := s.provider.evaClient.DeleteMessageTemplate(ctx, eva.DeleteMessageTemplateRequesst{
+			ID: variant.ID.Value,
+		})
+
+		if err != nil && !errors.Is(err, eva.ErrNotFound) {
+			diags.AddError("Deleting stencil variant failed.", fmt.Sprintf("Unable to delete variant %s, got error: %s", variant.key(), err))
+			return diags
+		}
+	}
+
+	return diags
+}
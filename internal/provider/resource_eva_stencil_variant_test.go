@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mad-it/terraform-provider-eva/internal/eva"
+	"github.com/mad-it/terraform-provider-eva/internal/evatest"
+)
+
+func TestStencilMatchVariants(t *testing.T) {
+	state := stencilTypeData{
+		Variants: []stencilVariantTypeData{
+			testStencilVariant(types.Int64{Value: 1}, "nl", "NL", "nl"),
+			testStencilVariant(types.Int64{Value: 2}, "en", "GB", "en"),
+		},
+	}
+	plan := stencilTypeData{
+		Variants: []stencilVariantTypeData{
+			testStencilVariant(types.Int64{Value: 1}, "en", "GB", "changed"),
+			testStencilVariant(types.Int64{Value: 2}, "de", "DE", "de"),
+			testStencilVariant(types.Int64{Null: true}, "nl", "NL", "nl"),
+		},
+	}
+
+	plan.matchVariants(state)
+
+	expected := []types.Int64{{Value: 2}, {Unknown: true}, {Value: 1}}
+
+	for i, variant := range plan.Variants {
+		if !variant.ID.Equal(expected[i]) {
+			t.Errorf("expected variant %s to have ID %v, got %v", variant.key(), expected[i], variant.ID)
+		}
+	}
+}
+
+func TestStencilPlanInsertedVariant(t *testing.T) {
+	ctx := context.Background()
+
+	prior := testStencilData(
+		testStencilVariant(types.Int64{Value: 1}, "nl", "NL", "nl"),
+		testStencilVariant(types.Int64{Value: 2}, "en", "GB", "en"),
+	)
+	prior.ID = types.Int64{Value: 10}
+	prior.ReferencedPartials = testStringList()
+
+	// Terraform copies the computed IDs of the variants at the same position in the state, so the German variant
+	// inserted before the Dutch variant is proposed with the ID of the Dutch variant.
+	proposed := testStencilData(
+		testStencilVariant(types.Int64{Value: 1}, "de", "DE", "de"),
+		testStencilVariant(types.Int64{Value: 2}, "nl", "NL", "nl"),
+	)
+	proposed.ID = prior.ID
+	proposed.ReferencedPartials = prior.ReferencedPartials
+
+	resp := testPlanResourceChange(t, "eva_stencil", &prior, &proposed)
+
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schema, _ := stencilType{}.GetSchema(ctx)
+	planned, err := resp.PlannedState.Unmarshal(schema.TerraformType(ctx))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var plan stencilTypeData
+
+	tfsdk.Plan{Schema: schema, Raw: planned}.Get(ctx, &plan)
+
+	expected := []types.Int64{{Unknown: true}, {Value: 1}}
+
+	if len(plan.Variants) != len(expected) {
+		t.Fatalf("expected %d variants, got %+v", len(expected), plan.Variants)
+	}
+
+	for i, variant := range plan.Variants {
+		if !variant.ID.Equal(expected[i]) {
+			t.Errorf("expected variant %s to have ID %v, got %v", variant.key(), expected[i], variant.ID)
+		}
+	}
+}
+
+func TestStencilValidateVariants(t *testing.T) {
+	stencil := testStencilVariant(types.Int64{Null: true}, "nl", "NL", "")
+
+	testCases := []struct {
+		name          string
+		variants      []stencilVariantTypeData
+		expectedPaths []*tftypes.AttributePath
+	}{
+		{
+			name: "unique variants",
+			variants: []stencilVariantTypeData{
+				testStencilVariant(types.Int64{Null: true}, "en", "GB", "en"),
+				testStencilVariant(types.Int64{Null: true}, "de", "DE", "de"),
+			},
+		},
+		{
+			name: "variant of the stencil",
+			variants: []stencilVariantTypeData{
+				testStencilVariant(types.Int64{Null: true}, "nl", "NL", "nl"),
+			},
+			expectedPaths: []*tftypes.AttributePath{stencilVariantsPath.WithElementKeyInt(0)},
+		},
+		{
+			name: "duplicate variants",
+			variants: []stencilVariantTypeData{
+				testStencilVariant(types.Int64{Null: true}, "en", "GB", "en"),
+				testStencilVariant(types.Int64{Null: true}, "en", "GB", "other"),
+			},
+			expectedPaths: []*tftypes.AttributePath{stencilVariantsPath.WithElementKeyInt(1)},
+		},
+		{
+			name: "unknown language",
+			variants: []stencilVariantTypeData{
+				testStencilVariant(types.Int64{Null: true}, "en", "GB", "en"),
+				{
+					LanguageID:         types.String{Unknown: true},
+					CountryID:          types.String{Value: "GB"},
+					OrganizationUnitID: types.Int64{Null: true},
+					Template:           types.String{Null: true},
+					TemplateFile:       types.String{Null: true},
+				},
+			},
+		},
+		{
+			name: "template and template file",
+			variants: []stencilVariantTypeData{
+				{
+					LanguageID:         types.String{Value: "en"},
+					CountryID:          types.String{Value: "GB"},
+					OrganizationUnitID: types.Int64{Null: true},
+					Template:           types.String{Value: "en"},
+					TemplateFile:       types.String{Value: "en.hbs"},
+				},
+			},
+			expectedPaths: []*tftypes.AttributePath{stencilVariantsPath.WithElementKeyInt(0).WithAttributeName("template_file")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diags := validateStencilVariants(stencil, testCase.variants)
+
+			if len(diags) != len(testCase.expectedPaths) {
+				t.Fatalf("expected %d diagnostics, got %v", len(testCase.expectedPaths), diags)
+			}
+
+			for i, d := range diags {
+				withPath, ok := d.(interface{ Path() *tftypes.AttributePath })
+
+				if !ok || !withPath.Path().Equal(testCase.expectedPaths[i]) {
+					t.Errorf("expected a diagnostic on %s, got %s: %s", testCase.expectedPaths[i], d.Summary(), d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestStencilCreateUpdateAndDeleteVariants(t *testing.T) {
+	ctx := context.Background()
+
+	server := evatest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	s := stencil{provider: testProvider(server)}
+	schema, _ := stencilType{}.GetSchema(ctx)
+
+	planned := testStencilData(
+		testStencilVariant(types.Int64{Unknown: true}, "nl", "NL", "<p>nl</p>"),
+		testStencilVariant(types.Int64{Unknown: true}, "en", "GB", "<p>en</p>"),
+	)
+
+	plan := tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, &planned)
+
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: schema}}
+	s.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+
+	for _, d := range createResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var created stencilTypeData
+
+	createResp.State.Get(ctx, &created)
+
+	if len(created.Variants) != 2 {
+		t.Fatalf("expected 2 variants, got %+v", created.Variants)
+	}
+
+	nl, en := created.Variants[0], created.Variants[1]
+
+	for _, variant := range created.Variants {
+		template, err := client.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{ID: variant.ID.Value})
+
+		if err != nil {
+			t.Fatalf("expected variant %s to be created, got error: %s", variant.key(), err)
+		}
+
+		if template.Name != "Stencil" || template.LanguageID != variant.LanguageID.Value || template.Template != variant.Template.Value {
+			t.Errorf("expected variant %s to be created with its own template, got %+v", variant.key(), template)
+		}
+	}
+
+	// The English variant is removed, the Dutch variant changes and a German variant is added.
+	planned = testStencilData(
+		testStencilVariant(types.Int64{Unknown: true}, "de", "DE", "<p>de</p>"),
+		testStencilVariant(types.Int64{Unknown: true}, "nl", "NL", "<p>nl changed</p>"),
+	)
+	planned.ID = created.ID
+	planned.matchVariants(created)
+
+	plan = tfsdk.Plan{Schema: schema}
+	plan.Set(ctx, &planned)
+
+	updateResp := tfsdk.UpdateResourceResponse{State: createResp.State}
+	s.Update(ctx, tfsdk.UpdateResourceRequest{Plan: plan, State: createResp.State}, &updateResp)
+
+	for _, d := range updateResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	var updated stencilTypeData
+
+	updateResp.State.Get(ctx, &updated)
+
+	// The variants are saved in the planned order.
+	if len(updated.Variants) != 2 || updated.Variants[1].key() != nl.key() || !updated.Variants[1].ID.Equal(nl.ID) {
+		t.Fatalf("expected the Dutch variant to be updated in place, got %+v", updated.Variants)
+	}
+
+	de := updated.Variants[0]
+
+	if de.key() != "de/DE/0" || de.ID.Null || de.ID.Unknown {
+		t.Fatalf("expected the German variant to be created, got %+v", de)
+	}
+
+	if _, err := client.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{ID: en.ID.Value}); !errors.Is(err, eva.ErrNotFound) {
+		t.Errorf("expected the English variant to be deleted, got %v", err)
+	}
+
+	template, err := client.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{ID: nl.ID.Value})
+
+	if err != nil || template.Template != "<p>nl changed</p>" {
+		t.Errorf("expected the template of the Dutch variant to be updated, got %+v, %v", template, err)
+	}
+
+	deleteResp := tfsdk.DeleteResourceResponse{State: updateResp.State}
+	s.Delete(ctx, tfsdk.DeleteResourceRequest{State: updateResp.State}, &deleteResp)
+
+	for _, d := range deleteResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	for _, id := range []types.Int64{updated.ID, nl.ID, de.ID} {
+		if _, err := client.GetMessageTemplateByID(ctx, eva.GetMessageTemplateByIDRequest{ID: id.Value}); !errors.Is(err, eva.ErrNotFound) {
+			t.Errorf("expected stencil %d to be deleted, got %v", id.Value, err)
+		}
+	}
+}
+
+func testStencilVariant(id types.Int64, languageID string, countryID string, template string) stencilVariantTypeData {
+	variant := stencilVariantTypeData{
+		ID:                 id,
+		LanguageID:         types.String{Value: languageID},
+		CountryID:          types.String{Value: countryID},
+		OrganizationUnitID: types.Int64{Null: true},
+		Template:           types.String{Value: template},
+		TemplateFile:       types.String{Null: true},
+		TemplateFileSHA256: types.String{Null: true},
+	}
+
+	if template == "" {
+		variant.Template = types.String{Null: true}
+	}
+
+	return variant
+}
+
+func testStencilData(variants ...stencilVariantTypeData) stencilTypeData {
+	return stencilTypeData{
+		ID:                    types.Int64{Unknown: true},
+		Name:                  types.String{Value: "Stencil"},
+		OrganizationUnitID:    types.Int64{Value: 1},
+		OrganizationUnitSetID: types.Int64{Null: true},
+		LanguageID:            types.String{Value: "en"},
+		CountryID:             types.String{Value: "US"},
+		Header:                types.String{Value: ""},
+		Template:              types.String{Value: "<p>stencil</p>"},
+		Footer:                types.String{Value: ""},
+		Helpers:               types.String{Value: ""},
+		HeaderFile:            types.String{Null: true},
+		HeaderFileSHA256:      types.String{Null: true},
+		TemplateFile:          types.String{Null: true},
+		TemplateFileSHA256:    types.String{Null: true},
+		FooterFile:            types.String{Null: true},
+		FooterFileSHA256:      types.String{Null: true},
+		HelpersFile:           types.String{Null: true},
+		HelpersFileSHA256:     types.String{Null: true},
+		Type:                  types.String{Value: "template"},
+		Layout:                types.String{Value: ""},
+		Destination:           types.Int64{Value: 1},
+		Variants:              variants,
+		ReferencedPartials:    types.List{Unknown: true, ElemType: types.StringType},
+		Timeouts:              timeouts{},
+	}
+}
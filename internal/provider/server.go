@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// stateUpgrader upgrades the state of a resource from one schema version to the next.
+type stateUpgrader func(state map[string]interface{}) error
+
+// stateUpgraders are the state upgraders of the resources by resource type and the schema version they upgrade from.
+var stateUpgraders = map[string]map[int64]stateUpgrader{
+	"eva_stencil": {
+		0: upgradeStencilStateV0,
+	},
+}
+
+// NewServer returns the protocol server of the provider.
+func NewServer(version string) tfprotov6.ProviderServer {
+	return &server{
+		ProviderServer: tfsdk.NewProtocol6Server(New(version)()),
+	}
+}
+
+// server upgrades the state of resources, as the framework does not support state upgrades yet.
+// All other requests are handled by the framework.
+type server struct {
+	tfprotov6.ProviderServer
+}
+
+func (s *server) UpgradeResourceState(ctx context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	upgraders := stateUpgraders[req.TypeName]
+
+	if req.RawState == nil || req.RawState.JSON == nil || upgraders[req.Version] == nil {
+		return s.ProviderServer.UpgradeResourceState(ctx, req)
+	}
+
+	var state map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&state); err != nil {
+		return upgradeResourceStateError("Unable to read the state, got error: %s", err), nil
+	}
+
+	for version := req.Version; upgraders[version] != nil; version++ {
+		tflog.Info(ctx, "Upgrading state.", "type", req.TypeName, "version", version)
+
+		if err := upgraders[version](state); err != nil {
+			return upgradeResourceStateError("Unable to upgrade the state from version %d, got error: %s", version, err), nil
+		}
+	}
+
+	upgradedState, err := json.Marshal(state)
+
+	if err != nil {
+		return upgradeResourceStateError("Unable to write the upgraded state, got error: %s", err), nil
+	}
+
+	return &tfprotov6.UpgradeResourceStateResponse{
+		UpgradedState: &tfprotov6.DynamicValue{
+			JSON: upgradedState,
+		},
+	}, nil
+}
+
+func upgradeResourceStateError(format string, args ...interface{}) *tfprotov6.UpgradeResourceStateResponse {
+	return &tfprotov6.UpgradeResourceStateResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityError,
+				Summary:  "Upgrading state failed.",
+				Detail:   fmt.Sprintf(format, args...),
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUpgradeStencilResourceState(t *testing.T) {
+	ctx := context.Background()
+
+	schema, _ := stencilType{}.GetSchema(ctx)
+
+	testCases := []struct {
+		name              string
+		version           int64
+		rawState          string
+		expectedError     bool
+		expectedType      types.String
+		expectedPaper     *paperPropertiesTypeData
+		expectedTemplates types.String
+	}{
+		{
+			name:    "version 0 with paper properties",
+			version: 0,
+			rawState: `{
+				"id": 1, "name": "Invoice", "organization_unit_id": 1, "language_id": "en", "country_id": "US",
+				"header": "", "template": "<p>{{Order.ID}}</p>", "footer": "", "helpers": "",
+				"type": 1, "layout": "", "destination": 4,
+				"paper_properties": {
+					"wait_for_network_idle": true, "wait_for_js": null, "size": null,
+					"format": 2, "orientation": 2, "margin": null, "thermal_printer_template_type": null
+				}
+			}`,
+			expectedType: types.String{Value: "1"},
+			expectedPaper: &paperPropertiesTypeData{
+				WaitForNetworkIdle:         types.Bool{Value: true},
+				WaitForJS:                  types.Bool{Null: true},
+				Format:                     types.String{Value: "2"},
+				Orientation:                types.String{Value: "2"},
+				ThermalPrinterTemplateType: types.Int64{Null: true},
+			},
+			expectedTemplates: types.String{Value: "<p>{{Order.ID}}</p>"},
+		},
+		{
+			name:    "version 0 without paper properties",
+			version: 0,
+			rawState: `{
+				"id": 2, "name": "Address", "organization_unit_id": 1, "language_id": "en", "country_id": "US",
+				"header": null, "template": "<p>{{Street}}</p>", "footer": null, "helpers": null,
+				"type": 2, "layout": null, "destination": 1, "paper_properties": null
+			}`,
+			expectedType:      types.String{Value: "2"},
+			expectedTemplates: types.String{Value: "<p>{{Street}}</p>"},
+		},
+		{
+			name:    "current version",
+			version: 1,
+			rawState: `{
+				"id": 3, "name": "Layout", "organization_unit_id": 1, "language_id": "en", "country_id": "US",
+				"template": "{{{body}}}", "type": "layout", "destination": 1, "timeouts": []
+			}`,
+			expectedType:      types.String{Value: "layout"},
+			expectedTemplates: types.String{Value: "{{{body}}}"},
+		},
+		{
+			name:          "invalid state",
+			version:       0,
+			rawState:      `{"id": `,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := NewServer("test").UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "eva_stencil",
+				Version:  testCase.version,
+				RawState: &tfprotov6.RawState{JSON: []byte(testCase.rawState)},
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expectedError {
+				if len(resp.Diagnostics) == 0 || resp.Diagnostics[0].Severity != tfprotov6.DiagnosticSeverityError {
+					t.Errorf("expected an error, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			raw, err := testUpgradedState(ctx, schema, resp.UpgradedState)
+
+			if err != nil {
+				t.Fatalf("unable to read the upgraded state: %s", err)
+			}
+
+			var actual stencilTypeData
+
+			if diags := (tfsdk.State{Schema: schema, Raw: raw}).Get(ctx, &actual); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !actual.Type.Equal(testCase.expectedType) {
+				t.Errorf("expected type %v, got %v", testCase.expectedType, actual.Type)
+			}
+
+			if !actual.Template.Equal(testCase.expectedTemplates) {
+				t.Errorf("expected template %v, got %v", testCase.expectedTemplates, actual.Template)
+			}
+
+			if actual.Destinations != nil {
+				t.Errorf("expected no destinations, got %v", actual.Destinations)
+			}
+
+			if toJSON(actual.PaperProperties) != toJSON(testCase.expectedPaper) {
+				t.Errorf("expected paper properties %s, got %s", toJSON(testCase.expectedPaper), toJSON(actual.PaperProperties))
+			}
+		})
+	}
+}
+
+// testUpgradedState reads the upgraded state like Terraform does, which sets the attributes that are missing from the
+// upgraded state to null. The JSON decoder of tftypes does not support missing attributes.
+func testUpgradedState(ctx context.Context, schema tfsdk.Schema, upgradedState *tfprotov6.DynamicValue) (tftypes.Value, error) {
+	var state map[string]json.RawMessage
+
+	if err := json.Unmarshal(upgradedState.JSON, &state); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	for name := range schema.TerraformType(ctx).(tftypes.Object).AttributeTypes {
+		if _, ok := state[name]; !ok {
+			state[name] = json.RawMessage("null")
+		}
+	}
+
+	upgradedJSON, err := json.Marshal(state)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	return (&tfprotov6.DynamicValue{JSON: upgradedJSON}).Unmarshal(schema.TerraformType(ctx))
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// defaultTimeout bounds an operation on a resource when no timeout is configured for it.
+	defaultTimeout = 20 * time.Minute
+	// defaultRequestTimeout bounds a single request to EVA when the provider has no request_timeout configured.
+	defaultRequestTimeout = time.Minute
+)
+
+// timeoutsBlock returns the block to configure how long the create, read, update and delete operations of a
+// resource may take. An operation can consist of multiple requests to EVA, the timeout applies to all of them.
+func timeoutsBlock() tfsdk.Block {
+	operationAttribute := func(operation string) tfsdk.Attribute {
+		return tfsdk.Attribute{
+			MarkdownDescription: "Timeout of " + operation + ", e.g. `30s` or `10m`. Defaults to `20m`.",
+			Optional:            true,
+			Type:                types.StringType,
+			Validators: []tfsdk.AttributeValidator{
+				durationValidator{},
+			},
+		}
+	}
+
+	return tfsdk.Block{
+		MarkdownDescription: "Timeouts of the operations on the resource.",
+		NestingMode:         tfsdk.BlockNestingModeList,
+		MaxItems:            1,
+		Attributes: map[string]tfsdk.Attribute{
+			"create": operationAttribute("creating the resource"),
+			"read":   operationAttribute("reading the resource"),
+			"update": operationAttribute("updating the resource"),
+			"delete": operationAttribute("deleting the resource"),
+		},
+	}
+}
+
+type timeoutsData struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeouts holds the timeouts block of a resource, which is configured at most once.
+type timeouts []timeoutsData
+
+// withTimeout bounds the context by the configured timeout, or by the default timeout when it is not configured.
+func withTimeout(ctx context.Context, timeout types.String) (context.Context, context.CancelFunc) {
+	duration := defaultTimeout
+
+	if !timeout.Null && !timeout.Unknown {
+		if configured, err := time.ParseDuration(timeout.Value); err == nil {
+			duration = configured
+		}
+	}
+
+	return context.WithTimeout(ctx, duration)
+}
+
+func (t timeouts) create(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(t) == 0 {
+		return withTimeout(ctx, types.String{Null: true})
+	}
+
+	return withTimeout(ctx, t[0].Create)
+}
+
+func (t timeouts) read(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(t) == 0 {
+		return withTimeout(ctx, types.String{Null: true})
+	}
+
+	return withTimeout(ctx, t[0].Read)
+}
+
+func (t timeouts) update(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(t) == 0 {
+		return withTimeout(ctx, types.String{Null: true})
+	}
+
+	return withTimeout(ctx, t[0].Update)
+}
+
+func (t timeouts) delete(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(t) == 0 {
+		return withTimeout(ctx, types.String{Null: true})
+	}
+
+	return withTimeout(ctx, t[0].Delete)
+}
+
+// dataSourceTimeoutsBlock returns the block to configure how long reading a data source may take.
+func dataSourceTimeoutsBlock() tfsdk.Block {
+	return tfsdk.Block{
+		MarkdownDescription: "Timeouts of the operations on the data source.",
+		NestingMode:         tfsdk.BlockNestingModeList,
+		MaxItems:            1,
+		Attributes: map[string]tfsdk.Attribute{
+			"read": {
+				MarkdownDescription: "Timeout of reading the data source, e.g. `30s` or `10m`. Defaults to `20m`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					durationValidator{},
+				},
+			},
+		},
+	}
+}
+
+type dataSourceTimeoutsData struct {
+	Read types.String `tfsdk:"read"`
+}
+
+// dataSourceTimeouts holds the timeouts block of a data source, which is configured at most once.
+type dataSourceTimeouts []dataSourceTimeoutsData
+
+func (t dataSourceTimeouts) read(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(t) == 0 {
+		return withTimeout(ctx, types.String{Null: true})
+	}
+
+	return withTimeout(ctx, t[0].Read)
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type stringOneOfValidator struct {
+	Values []string
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Value must be one of: %s", strings.Join(v.Values, ", "))
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Value must be one of: `%s`", strings.Join(v.Values, "`, `"))
+}
+
+// Validate runs the logic of the validator.
+// Unknown and null values are skipped, they are validated once they are known.
+func (v stringOneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var str types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &str)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if str.Unknown || str.Null {
+		return
+	}
+
+	for _, value := range v.Values {
+		if str.Value == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid value.",
+		fmt.Sprintf("Got %q, expected one of: %s.", str.Value, strings.Join(v.Values, ", ")),
+	)
+}
+
+type stringRegexValidator struct {
+	Regex *regexp.Regexp
+	// Format is a human readable representation of the expected format, e.g. HH:MM.
+	Format string
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v stringRegexValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Value must be formatted as %s", v.Format)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v stringRegexValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Value must be formatted as `%s`", v.Format)
+}
+
+// Validate runs the logic of the validator.
+// Unknown and null values are skipped, they are validated once they are known.
+func (v stringRegexValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var str types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &str)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if str.Unknown || str.Null {
+		return
+	}
+
+	if !v.Regex.MatchString(str.Value) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid value.",
+			fmt.Sprintf("Got %q, expected a value formatted as %s.", str.Value, v.Format),
+		)
+	}
+}
+
+var (
+	dateValidator = stringRegexValidator{
+		Regex:  regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+		Format: "YYYY-MM-DD",
+	}
+	timeOfDayValidator = stringRegexValidator{
+		Regex:  regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`),
+		Format: "HH:MM",
+	}
+)
+
+type enumValidator struct {
+	Enum enum
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v enumValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Value must be one of: %s", strings.Join(v.Enum.names(), ", "))
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v enumValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Value must be one of: %s", v.Enum.markdownDescription())
+}
+
+// Validate runs the logic of the validator.
+// Unknown and null values are skipped, they are validated once they are known.
+func (v enumValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var str types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &str)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if str.Unknown || str.Null {
+		return
+	}
+
+	if _, ok := v.Enum.toValue(str.Value); !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid value.",
+			fmt.Sprintf("Got %q, expected one of: %s.", str.Value, strings.Join(v.Enum.names(), ", ")),
+		)
+	}
+}
+
+type durationValidator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v durationValidator) Description(ctx context.Context) string {
+	return "Value must be a duration, e.g. 30s or 10m"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a duration, e.g. `30s` or `10m`"
+}
+
+// Validate runs the logic of the validator.
+// Unknown and null values are skipped, they are validated once they are known.
+func (v durationValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var str types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &str)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if str.Unknown || str.Null {
+		return
+	}
+
+	if duration, err := time.ParseDuration(str.Value); err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid value.",
+			fmt.Sprintf("Got %q, expected a positive duration like 30s or 10m.", str.Value),
+		)
+	}
+}
+
+// validateConflictingAttributes adds an error when more than one of the given top level attributes is configured.
+// When required is set, an error is also added when none of them is configured.
+func validateConflictingAttributes(config tfsdk.Config, required bool, names ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var attributes map[string]tftypes.Value
+
+	if err := config.Raw.As(&attributes); err != nil {
+		diags.AddError("Reading configuration failed.", fmt.Sprintf("Unable to read configuration, got error: %s", err))
+		return diags
+	}
+
+	var configured []string
+
+	for _, name := range names {
+		if value, ok := attributes[name]; ok && !value.IsNull() {
+			configured = append(configured, name)
+		}
+	}
+
+	if len(configured) > 1 {
+		diags.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName(configured[1]),
+			"Conflicting attributes.",
+			fmt.Sprintf("Only one of %s can be configured.", strings.Join(names, ", ")),
+		)
+	}
+
+	if required && len(configured) == 0 {
+		diags.AddError("Missing attribute.", fmt.Sprintf("One of %s must be configured.", strings.Join(names, ", ")))
+	}
+
+	return diags
+}
+
+type handlebarsValidator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v handlebarsValidator) Description(ctx context.Context) string {
+	return "Value should be a valid Handlebars template, templates which can't be parsed are reported as a warning"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v handlebarsValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value should be a valid [Handlebars](https://handlebarsjs.com/) template, templates which can't be parsed are reported as a warning"
+}
+
+// Validate runs the logic of the validator.
+// Unknown and null values are skipped, they are validated once they are known. Only the syntax of Handlebars 3 can
+// be parsed, so parse errors are warnings.
+func (v handlebarsValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var str types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &str)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if str.Unknown || str.Null {
+		return
+	}
+
+	if _, err := parseHandlebars(str.Value); err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			req.AttributePath,
+			"Unable to validate template.",
+			handlebarsParseWarning(err),
+		)
+	}
+}
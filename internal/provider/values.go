@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// EVA does not distinguish between a field that is not set and a field that has its zero value.
+// These helpers map zero values returned by EVA to null, so optional attributes which are not
+// configured don't show up as a difference in the plan.
+
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.String{Null: true}
+	}
+
+	return types.String{Value: value}
+}
+
+func int64ValueOrNull(value int64) types.Int64 {
+	if value == 0 {
+		return types.Int64{Null: true}
+	}
+
+	return types.Int64{Value: value}
+}
+
+// dateValueOrNull only keeps the date part of the timestamps EVA returns for dates.
+func dateValueOrNull(value string) types.String {
+	if len(value) > len("2006-01-02") {
+		return types.String{Value: value[:len("2006-01-02")]}
+	}
+
+	return stringValueOrNull(value)
+}
+
+// timeOfDayValueOrNull only keeps the hours and minutes of the times EVA returns, e.g. 09:00:00.
+func timeOfDayValueOrNull(value string) types.String {
+	if len(value) > len("15:04") {
+		return types.String{Value: value[:len("15:04")]}
+	}
+
+	return stringValueOrNull(value)
+}
+
+// For fields EVA can leave out, a null value and zero value can be told apart. A zero value is only kept
+// when the attribute is already set to it, otherwise it is mapped to null like the helpers above.
+// Booleans which are only ever configured to be true, like `primary`, are handled the same way.
+
+func int64PointerValueOrNull(current types.Int64, value *int64) types.Int64 {
+	if value == nil || (*value == 0 && current.Null) {
+		return types.Int64{Null: true}
+	}
+
+	return types.Int64{Value: *value}
+}
+
+func float64PointerValueOrNull(current types.Float64, value *float64) types.Float64 {
+	if value == nil || (*value == 0 && current.Null) {
+		return types.Float64{Null: true}
+	}
+
+	return types.Float64{Value: *value}
+}
+
+func stringPointer(value types.String) *string {
+	if value.Null || value.Unknown {
+		return nil
+	}
+
+	return &value.Value
+}
+
+func int64Pointer(value types.Int64) *int64 {
+	if value.Null || value.Unknown {
+		return nil
+	}
+
+	return &value.Value
+}
+
+func float64Pointer(value types.Float64) *float64 {
+	if value.Null || value.Unknown {
+		return nil
+	}
+
+	return &value.Value
+}
+
+func int64OrZero(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
@@ -1,10 +1,10 @@
 package main
 
 import (
-	"context"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 
 	"github.com/mad-it/terraform-provider-eva/internal/provider"
 )
@@ -21,12 +21,12 @@ var (
 )
 
 func main() {
-	opts := tfsdk.ServeOpts{
-		// TODO: Update this string with the published name of your provider.
-		Name: "registry.terraform.io/mad-it/eva",
-	}
+	// TODO: Update this string with the published name of your provider.
+	name := "registry.terraform.io/mad-it/eva"
 
-	err := tfsdk.Serve(context.Background(), provider.New(version), opts)
+	err := tf6server.Serve(name, func() tfprotov6.ProviderServer {
+		return provider.NewServer(version)
+	})
 
 	if err != nil {
 		log.Fatal(err.Error())